@@ -1,16 +1,33 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"slices"
+	"strings"
 
 	"github.com/cffnpwr/git-cz-go/config"
 	"github.com/cffnpwr/git-cz-go/internal/app"
+	"github.com/cffnpwr/git-cz-go/internal/model"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 var (
 	configPath string
+
+	typeFlag     string
+	scopeFlag    string
+	ticketFlag   string
+	subjectFlag  string
+	bodyFlag     string
+	breakingFlag string
+	footerFlags  []string
+	yesFlag      bool
+	dryRunFlag   bool
+	outputFlag   string
+	fromFileFlag string
 )
 var rootCmd = &cobra.Command{
 	Use:   "git-cz",
@@ -24,18 +41,101 @@ var rootCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
-		err = app.Run(cfg)
+		if !slices.Contains(app.OutputFormats, outputFlag) {
+			fmt.Fprintf(os.Stderr, "Error reading flags: invalid output format: %s\n", outputFlag)
+			os.Exit(1)
+		}
+
+		prefill, err := buildPrefill(cmd, cfg)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error running app: %s\n", err)
+			fmt.Fprintf(os.Stderr, "Error reading flags: %s\n", err)
 			os.Exit(1)
 		}
+
+		err = app.Run(cfg, app.Options{
+			Prefill: prefill,
+			Yes:     yesFlag,
+			DryRun:  dryRunFlag || cmd.Flags().Changed("output"),
+			Output:  outputFlag,
+
+			AnswersFile: fromFileFlag,
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error running app: %s\n", err)
+
+			code := app.ExitCodeError
+			var exitErr *app.ExitError
+			if errors.As(err, &exitErr) {
+				code = exitErr.Code
+			}
+			os.Exit(code)
+		}
 	},
 }
 
+// buildPrefill はフラグで指定された値から回答済みのステージを組み立てる
+func buildPrefill(cmd *cobra.Command, cfg *config.Config) (model.Prefill, error) {
+	var p model.Prefill
+	flags := cmd.Flags()
+
+	if flags.Changed("type") {
+		t, err := model.ResolveType(cfg, typeFlag)
+		if err != nil {
+			return model.Prefill{}, err
+		}
+		p.Data.Type = t
+		p.Answered = append(p.Answered, model.StageTypeSelect)
+	}
+	if flags.Changed("scope") {
+		p.Data.Scope = scopeFlag
+		p.Answered = append(p.Answered, model.StageScope)
+	}
+	if flags.Changed("ticket") {
+		p.Data.TicketNumber = model.FormatTicketNumber(cfg, ticketFlag)
+		p.Answered = append(p.Answered, model.StageTicketNumber)
+	}
+	if flags.Changed("subject") {
+		p.Data.Subject = subjectFlag
+		p.Answered = append(p.Answered, model.StageSubject)
+	}
+	if flags.Changed("body") {
+		p.Data.Body = bodyFlag
+		p.Answered = append(p.Answered, model.StageBody)
+	}
+	if flags.Changed("breaking") {
+		p.Data.IsBreaking = true
+		p.Data.BreakingChanges = breakingFlag
+		p.Answered = append(p.Answered, model.StageBreaking)
+	}
+	if flags.Changed("footer") {
+		p.Data.Footer = strings.Join(footerFlags, "\n")
+		p.Answered = append(p.Answered, model.StageFooter)
+	}
+
+	return p, nil
+}
+
 func Execute() error {
 	return rootCmd.Execute()
 }
 
+// addPrefillFlags は回答を事前に指定するフラグを登録する
+func addPrefillFlags(flags *pflag.FlagSet) {
+	flags.StringVarP(&typeFlag, "type", "t", "", "commit type (type name such as 'feat' or full configured value)")
+	flags.StringVarP(&scopeFlag, "scope", "s", "", "commit scope")
+	flags.StringVar(&ticketFlag, "ticket", "", "ticket number without prefix")
+	flags.StringVarP(&subjectFlag, "subject", "m", "", "commit subject")
+	flags.StringVarP(&bodyFlag, "body", "b", "", "commit body")
+	flags.StringVar(&breakingFlag, "breaking", "", "breaking change description (marks the commit as breaking)")
+	flags.StringArrayVarP(&footerFlags, "footer", "f", nil, "footer line such as 'Refs: #123' (repeatable)")
+}
+
 func init() {
 	rootCmd.PersistentFlags().StringVarP(&configPath, "config", "c", "./config/config.yaml", "config file path")
+
+	addPrefillFlags(rootCmd.Flags())
+	rootCmd.Flags().BoolVarP(&yesFlag, "yes", "y", false, "commit without starting the interactive wizard")
+	rootCmd.Flags().BoolVar(&dryRunFlag, "dry-run", false, "print the message to stdout instead of committing")
+	rootCmd.Flags().StringVar(&fromFileFlag, "from-file", "", "commit using a YAML/JSON answers file ('-' for stdin) without the wizard")
+	rootCmd.Flags().StringVarP(&outputFlag, "output", "o", app.OutputText, "print the message in the given format (text|json) instead of committing")
 }
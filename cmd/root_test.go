@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/cffnpwr/git-cz-go/config"
+	"github.com/cffnpwr/git-cz-go/internal/model"
+	"github.com/google/go-cmp/cmp"
+	"github.com/spf13/cobra"
+)
+
+func TestBuildPrefill(t *testing.T) {
+	cfg := &config.Config{
+		Types: []config.TypeValue{
+			{Value: "feat: :sparkles:", Name: "feat"},
+			{Value: "fix: :bug:", Name: "fix"},
+		},
+		TicketNumber: config.TicketNumber{
+			Enable:       true,
+			Prefix:       "#",
+			MatchPattern: (*config.Regexp)(regexp.MustCompile(`^\d+$`)),
+		},
+	}
+
+	tests := []struct {
+		name    string
+		args    []string
+		want    model.Prefill
+		wantErr bool
+	}{
+		{
+			name: "[正常系] フラグが無い場合は回答なし",
+		},
+		{
+			name: "[正常系] 指定したフラグのステージを回答済みにする",
+			args: []string{"--type", "fix", "--ticket", "12", "-m", "fix bug", "-f", "Refs: #1", "-f", "Closes: #2"},
+			want: model.Prefill{
+				Data: model.CommitData{
+					Type:         "fix: :bug:",
+					TicketNumber: "#12",
+					Subject:      "fix bug",
+					Footer:       "Refs: #1\nCloses: #2",
+				},
+				Answered: []model.Stage{model.StageTypeSelect, model.StageTicketNumber, model.StageSubject, model.StageFooter},
+			},
+		},
+		{
+			name: "[正常系] 空の値も回答として扱う",
+			args: []string{"--scope", "", "--breaking", "drop the v1 API"},
+			want: model.Prefill{
+				Data:     model.CommitData{IsBreaking: true, BreakingChanges: "drop the v1 API"},
+				Answered: []model.Stage{model.StageScope, model.StageBreaking},
+			},
+		},
+		{
+			name:    "[異常系] 未知のタイプ",
+			args:    []string{"--type", "perf"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			addPrefillFlags(cmd.Flags())
+			if err := cmd.ParseFlags(tt.args); err != nil {
+				t.Fatalf("ParseFlags() error = %v", err)
+			}
+
+			got, err := buildPrefill(cmd, cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("buildPrefill() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("buildPrefill() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -5,7 +5,11 @@ import (
 	"os"
 	"regexp"
 	"slices"
+	"strconv"
+	"strings"
+	"text/template"
 
+	"github.com/cffnpwr/git-cz-go/pkg/theme"
 	"gopkg.in/yaml.v3"
 )
 
@@ -28,11 +32,75 @@ func (r *Regexp) MarshalText() ([]byte, error) {
 	return nil, nil
 }
 
+const (
+	PreviewPositionBottom = "bottom"
+	PreviewPositionRight  = "right"
+	PreviewPositionNone   = "none"
+)
+
+var allowedPreviewPositions = []string{
+	PreviewPositionBottom,
+	PreviewPositionRight,
+	PreviewPositionNone,
+}
+
 var allowedSkipQuestions = []string{
 	"scope",
 	"body",
 	"breaking",
 	"footer",
+	"co_authors",
+}
+
+// allowedTypeSkipQuestions is allowedSkipQuestions plus the questions only a type can skip
+var allowedTypeSkipQuestions = append([]string{"ticket_number"}, allowedSkipQuestions...)
+
+var allowedRequiredQuestions = []string{
+	"scope",
+	"ticket_number",
+	"body",
+	"footer",
+	"co_authors",
+}
+
+const (
+	QuestionKindText        = "text"
+	QuestionKindTextarea    = "textarea"
+	QuestionKindSelect      = "select"
+	QuestionKindConfirm     = "confirm"
+	QuestionKindMultiselect = "multiselect"
+)
+
+var allowedQuestionKinds = []string{
+	QuestionKindText,
+	QuestionKindTextarea,
+	QuestionKindSelect,
+	QuestionKindConfirm,
+	QuestionKindMultiselect,
+}
+
+// BuiltinFields are the answers of the built-in questions a condition can refer to
+var BuiltinFields = []string{
+	"type",
+	"scope",
+	"ticket_number",
+	"subject",
+	"body",
+	"breaking",
+	"footer",
+	"co_authors",
+}
+
+// questionIDPattern
+// テンプレートから`.Custom.id`として参照できる識別子
+var questionIDPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// TemplateFuncs are the functions available in the message templates besides the text/template built-ins
+var TemplateFuncs = template.FuncMap{
+	"join":  strings.Join,
+	"trim":  strings.TrimSpace,
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
 }
 
 type Config struct {
@@ -41,17 +109,161 @@ type Config struct {
 	SkipQuestions        SkipQuestions `yaml:"skip_questions,omitempty"`
 	AllowBreakingChanges []string      `yaml:"allow_breaking_changes,omitempty"`
 	TicketNumber         TicketNumber  `yaml:"ticket_number,omitempty"`
+	CoAuthors            []CoAuthor    `yaml:"co_authors,omitempty"`
+	Header               Header        `yaml:"header,omitempty"`
+	Body                 Body          `yaml:"body,omitempty"`
+	Preview              Preview       `yaml:"preview,omitempty"`
+	Questions            []Question    `yaml:"questions,omitempty"`
+	Template             Template      `yaml:"template,omitempty"`
+	Mouse                bool          `yaml:"mouse,omitempty"`      // Select items and answer confirmations by clicking
+	AltScreen            bool          `yaml:"alt_screen,omitempty"` // Show the wizard in the alternate screen, leaving only the summary when it ends
+	Keys                 Keys          `yaml:"keys,omitempty"`
+	Theme                Theme         `yaml:"theme,omitempty"`
+}
+
+// Theme picks a built-in theme and overrides its colors, written as "#rrggbb" or an ANSI color number
+type Theme struct {
+	Preset       string `yaml:"preset,omitempty"` // auto, dark, light, high-contrast or none
+	Accent       string `yaml:"accent,omitempty"`
+	Muted        string `yaml:"muted,omitempty"`
+	Error        string `yaml:"error,omitempty"`
+	Warning      string `yaml:"warning,omitempty"`
+	Group        string `yaml:"group,omitempty"`
+	Description  string `yaml:"description,omitempty"`
+	ButtonFg     string `yaml:"button_fg,omitempty"`
+	ButtonBg     string `yaml:"button_bg,omitempty"`
+	ButtonIdleBg string `yaml:"button_idle_bg,omitempty"`
+	Border       string `yaml:"border,omitempty"` // rounded, normal, thick, double or hidden
+}
+
+// Colors returns the color overrides keyed by their name in the config
+func (t Theme) Colors() map[string]string {
+	return map[string]string{
+		"accent":         t.Accent,
+		"muted":          t.Muted,
+		"error":          t.Error,
+		"warning":        t.Warning,
+		"group":          t.Group,
+		"description":    t.Description,
+		"button_fg":      t.ButtonFg,
+		"button_bg":      t.ButtonBg,
+		"button_idle_bg": t.ButtonIdleBg,
+	}
+}
+
+// Keys maps the actions to the keys triggering them, the actions left out keep their default keys
+type Keys struct {
+	Quit   []string `yaml:"quit,omitempty"`   // Quit without committing
+	Back   []string `yaml:"back,omitempty"`   // Go back to the previous question
+	Enter  []string `yaml:"enter,omitempty"`  // Finish a single-line input or the multi-select
+	Submit []string `yaml:"submit,omitempty"` // Finish a multi-line input
+	Bullet []string `yaml:"bullet,omitempty"` // Start a new bullet in the body
+	Up     []string `yaml:"up,omitempty"`     // Move the cursor up in lists
+	Down   []string `yaml:"down,omitempty"`   // Move the cursor down in lists
+	Toggle []string `yaml:"toggle,omitempty"` // Check or uncheck the item under the cursor
+	Select []string `yaml:"select,omitempty"` // Choose the item or option under the cursor
+	Prev   []string `yaml:"prev,omitempty"`   // Move to the previous option of a confirmation
+	Next   []string `yaml:"next,omitempty"`   // Move to the next option of a confirmation
+	Yes    []string `yaml:"yes,omitempty"`    // Answer yes, or commit at the confirm stage
+	No     []string `yaml:"no,omitempty"`     // Answer no, or cancel at the confirm stage
+	Edit   []string `yaml:"edit,omitempty"`   // Edit the message in the editor at the confirm stage
+	Help   []string `yaml:"help,omitempty"`   // Show or hide the full help
+}
+
+// KeyAction is an action with the keys configured for it
+type KeyAction struct {
+	Name string
+	Keys []string // nil when the action keeps its default keys
+}
+
+// Actions returns the actions in a fixed order
+func (k Keys) Actions() []KeyAction {
+	return []KeyAction{
+		{"quit", k.Quit},
+		{"back", k.Back},
+		{"enter", k.Enter},
+		{"submit", k.Submit},
+		{"bullet", k.Bullet},
+		{"up", k.Up},
+		{"down", k.Down},
+		{"toggle", k.Toggle},
+		{"select", k.Select},
+		{"prev", k.Prev},
+		{"next", k.Next},
+		{"yes", k.Yes},
+		{"no", k.No},
+		{"edit", k.Edit},
+		{"help", k.Help},
+	}
+}
+
+// Question is a custom question asked after the built-in ones
+type Question struct {
+	ID       string     `yaml:"id"`                 // Name of the answer, referenced as `.Custom.<id>` in the templates
+	Kind     string     `yaml:"kind,omitempty"`     // text (default), textarea, select, confirm or multiselect
+	Prompt   string     `yaml:"prompt,omitempty"`   // Question shown to the user, the id if not set
+	Validate *Regexp    `yaml:"validate,omitempty"` // Pattern a non-empty answer must match, each item for multiselect
+	Choices  []string   `yaml:"choices,omitempty"`  // Items of select and multiselect
+	Required bool       `yaml:"required,omitempty"` // Require a non-empty answer
+	When     *Condition `yaml:"when,omitempty"`     // Ask the question only when an earlier answer matches
+}
+
+// GetKind returns the kind of the question, text if not set
+func (q Question) GetKind() string {
+	if q.Kind == "" {
+		return QuestionKindText
+	}
+	return q.Kind
+}
+
+// Condition matches an earlier answer
+type Condition struct {
+	Field  string   `yaml:"field"`            // A built-in field or the id of an earlier question
+	Equals []string `yaml:"equals,omitempty"` // Values the answer must be one of, any non-empty answer if not set
+}
+
+// Template holds text/template sources the sections of the commit message are rendered with
+type Template struct {
+	Header string `yaml:"header,omitempty"` // First line, the conventional header if not set
+	Body   string `yaml:"body,omitempty"`   // Body, the answered body if not set
+	Footer string `yaml:"footer,omitempty"` // Footer, breaking changes, footer and co-authors if not set
 }
 
 type TypeValue struct {
-	Value string `yaml:"value"`
-	Name  string `yaml:"name"`
+	Value        string `yaml:"value"`
+	Name         string `yaml:"name"`
+	Description  string `yaml:"description,omitempty"`   // Detail shown under the type list while the type is under the cursor
+	Group        string `yaml:"group,omitempty"`         // Header the type is listed under
+	Disabled     bool   `yaml:"disabled,omitempty"`      // Keep the type in the list without letting it be selected
+	BodyTemplate string `yaml:"body_template,omitempty"` // Text the body is seeded with, lines ending with `:` are section headings
+	BodyRequired bool   `yaml:"body_required,omitempty"` // Require a body, or every section of the template when it has one
+
+	SkipQuestions     SkipQuestions    `yaml:"skip_questions,omitempty"`     // Questions not asked for this type in addition to the global ones
+	RequiredQuestions []string         `yaml:"required_questions,omitempty"` // Questions that must be answered for this type
+	Defaults          QuestionDefaults `yaml:"defaults,omitempty"`           // Values the questions are prefilled with for this type
+}
+
+// QuestionDefaults holds the per-type default answers
+type QuestionDefaults struct {
+	Scope        string `yaml:"scope,omitempty"`
+	TicketNumber string `yaml:"ticket_number,omitempty"`
+	Body         string `yaml:"body,omitempty"`
+	Footer       string `yaml:"footer,omitempty"`
 }
 
 func (t TypeValue) String() string {
 	return t.Name
 }
 
+// typeNamePattern
+// `feat: :sparkles:`のような値の先頭にあるタイプ名
+var typeNamePattern = regexp.MustCompile(`^[^\s:(!]+`)
+
+// TypeName returns the conventional commit type (e.g. `feat`) of the value
+func (t TypeValue) TypeName() string {
+	return typeNamePattern.FindString(t.Value)
+}
+
 type Messages struct {
 	Type            string `yaml:"type,omitempty"`
 	Scope           string `yaml:"scope,omitempty"`
@@ -61,7 +273,18 @@ type Messages struct {
 	BreakingConfirm string `yaml:"breaking_confirm,omitempty"`
 	BreakingMessage string `yaml:"breaking_message,omitempty"`
 	Footer          string `yaml:"footer,omitempty"`
+	CoAuthors       string `yaml:"co_authors,omitempty"`
 	ConfirmCommit   string `yaml:"confirm_commit,omitempty"`
+
+	ConfirmOptions ConfirmOptions `yaml:"confirm_options,omitempty"`
+}
+
+// ConfirmOptions holds the labels of the options at the final confirmation
+type ConfirmOptions struct {
+	Yes    string `yaml:"yes,omitempty"`
+	Edit   string `yaml:"edit,omitempty"`
+	Back   string `yaml:"back,omitempty"`
+	Cancel string `yaml:"cancel,omitempty"`
 }
 
 type SkipQuestions []string
@@ -74,11 +297,145 @@ type TicketNumber struct {
 	FromBranchName FromBranchName `yaml:"from_branch_name,omitempty"`
 }
 
+type Header struct {
+	MaxLength        *Rule[int]      `yaml:"max_length,omitempty"`         // Maximum width of the header in display columns, 72 is shown in the preview if not set
+	MinLength        *Rule[int]      `yaml:"min_length,omitempty"`         // Minimum width of the header in display columns
+	SubjectCase      *Rule[Case]     `yaml:"subject_case,omitempty"`       // Case of the subject
+	NoTrailingPeriod *Rule[bool]     `yaml:"no_trailing_period,omitempty"` // Disallow a period at the end of the subject
+	ForbiddenWords   *Rule[[]string] `yaml:"forbidden_words,omitempty"`    // Words that must not appear in the subject
+	Imperative       *Rule[bool]     `yaml:"imperative,omitempty"`         // Require the subject to start with an imperative verb
+	Required         *Rule[bool]     `yaml:"required,omitempty"`           // Require a non-empty subject, enabled if not set
+}
+
+type Body struct {
+	WrapWidth     int        `yaml:"wrap_width,omitempty"`      // Width in display columns the body is wrapped at, 72 if not set
+	Rewrap        *bool      `yaml:"rewrap,omitempty"`          // Re-wrap the body when it is submitted, enabled if not set
+	MaxLineLength *Rule[int] `yaml:"max_line_length,omitempty"` // Maximum width of a body line in display columns
+}
+
+const defaultBodyWrapWidth = 72
+
+// GetWrapWidth returns the configured wrap width or the default
+func (b Body) GetWrapWidth() int {
+	if b.WrapWidth > 0 {
+		return b.WrapWidth
+	}
+	return defaultBodyWrapWidth
+}
+
+// ShouldRewrap reports whether the body is re-wrapped on submit
+func (b Body) ShouldRewrap() bool {
+	return b.Rewrap == nil || *b.Rewrap
+}
+
+type Case string
+
+const (
+	CaseLower    Case = "lower"
+	CaseSentence Case = "sentence"
+	CaseAny      Case = "any"
+)
+
+var allowedCases = []Case{CaseLower, CaseSentence, CaseAny}
+
+func (c *Case) UnmarshalText(b []byte) error {
+	v := Case(b)
+	if !slices.Contains(allowedCases, v) {
+		return fmt.Errorf("invalid case: %s", v)
+	}
+	*c = v
+	return nil
+}
+
+type RuleLevel string
+
+const (
+	RuleLevelError   RuleLevel = "error"
+	RuleLevelWarning RuleLevel = "warning"
+)
+
+func (l *RuleLevel) UnmarshalText(b []byte) error {
+	v := RuleLevel(b)
+	if v != RuleLevelError && v != RuleLevelWarning {
+		return fmt.Errorf("invalid rule level: %s", v)
+	}
+	*l = v
+	return nil
+}
+
+// Rule is a validation rule written either as a bare value or as `{value: ..., level: error|warning}`
+type Rule[T any] struct {
+	Value T
+	Level RuleLevel // error if not set
+}
+
+func (r *Rule[T]) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.MappingNode {
+		r.Level = RuleLevelError
+		return node.Decode(&r.Value)
+	}
+
+	var v struct {
+		Value T         `yaml:"value"`
+		Level RuleLevel `yaml:"level,omitempty"`
+	}
+	if err := node.Decode(&v); err != nil {
+		return err
+	}
+	r.Value = v.Value
+	r.Level = v.Level
+	if r.Level == "" {
+		r.Level = RuleLevelError
+	}
+	return nil
+}
+
+// IsWarning reports whether a violation of the rule only warns instead of blocking
+func (r Rule[T]) IsWarning() bool {
+	return r.Level == RuleLevelWarning
+}
+
+type Preview struct {
+	Position string `yaml:"position,omitempty"` // Where the live preview is shown: bottom (default), right or none
+}
+
 type FromBranchName struct {
 	Enable        bool    `yaml:"enable,omitempty"`
 	ExtractRegexp *Regexp `yaml:"extract_regexp,omitempty"`
 }
 
+// CoAuthor is an identity written as `Name <email>`
+type CoAuthor struct {
+	Name  string
+	Email string
+}
+
+func (c *CoAuthor) UnmarshalText(b []byte) error {
+	s := strings.TrimSpace(string(b))
+	start := strings.LastIndex(s, "<")
+	if start < 0 || !strings.HasSuffix(s, ">") {
+		return fmt.Errorf("invalid co-author %q, must be 'Name <email>'", s)
+	}
+
+	name := strings.TrimSpace(s[:start])
+	email := strings.TrimSpace(s[start+1 : len(s)-1])
+	if name == "" || email == "" {
+		return fmt.Errorf("invalid co-author %q, must be 'Name <email>'", s)
+	}
+	c.Name = name
+	c.Email = email
+
+	return nil
+}
+
+func (c CoAuthor) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+func (c CoAuthor) String() string {
+	return c.Name + " <" + c.Email + ">"
+}
+
 func LoadConfig(path string) (*Config, error) {
 	b, err := os.ReadFile(path)
 	if err != nil {
@@ -96,5 +453,106 @@ func LoadConfig(path string) (*Config, error) {
 			return nil, fmt.Errorf("invalid skip question: %s", s)
 		}
 	}
+	if len(cfg.Types) > 0 && !slices.ContainsFunc(cfg.Types, func(t TypeValue) bool { return !t.Disabled }) {
+		return nil, fmt.Errorf("invalid types, at least one type must be enabled")
+	}
+	for _, t := range cfg.Types {
+		for _, s := range t.SkipQuestions {
+			if !slices.Contains(allowedTypeSkipQuestions, s) {
+				return nil, fmt.Errorf("invalid skip question for type %s: %s", t.Value, s)
+			}
+		}
+		for _, s := range t.RequiredQuestions {
+			if !slices.Contains(allowedRequiredQuestions, s) {
+				return nil, fmt.Errorf("invalid required question for type %s: %s", t.Value, s)
+			}
+		}
+	}
+	if cfg.Preview.Position != "" && !slices.Contains(allowedPreviewPositions, cfg.Preview.Position) {
+		return nil, fmt.Errorf("invalid preview position: %s", cfg.Preview.Position)
+	}
+	if r := cfg.Header.MaxLength; r != nil && r.Value < 1 {
+		return nil, fmt.Errorf("invalid header max length: %d", r.Value)
+	}
+	if r := cfg.Header.MinLength; r != nil && r.Value < 0 {
+		return nil, fmt.Errorf("invalid header min length: %d", r.Value)
+	}
+	if cfg.Body.WrapWidth < 0 {
+		return nil, fmt.Errorf("invalid body wrap width: %d", cfg.Body.WrapWidth)
+	}
+	if r := cfg.Body.MaxLineLength; r != nil && r.Value < 1 {
+		return nil, fmt.Errorf("invalid body max line length: %d", r.Value)
+	}
+	if err := validateQuestions(cfg.Questions); err != nil {
+		return nil, err
+	}
+	for _, a := range cfg.Keys.Actions() {
+		// 空のリストを指定するとその操作ができなくなる
+		if a.Keys != nil && len(a.Keys) == 0 {
+			return nil, fmt.Errorf("invalid keys for %s: at least one key is required", a.Name)
+		}
+		if slices.Contains(a.Keys, "") {
+			return nil, fmt.Errorf("invalid keys for %s: empty key", a.Name)
+		}
+	}
+	if err := validateTheme(cfg.Theme); err != nil {
+		return nil, err
+	}
+	for name, src := range map[string]string{"header": cfg.Template.Header, "body": cfg.Template.Body, "footer": cfg.Template.Footer} {
+		if _, err := template.New(name).Funcs(TemplateFuncs).Parse(src); err != nil {
+			return nil, fmt.Errorf("invalid %s template: %w", name, err)
+		}
+	}
 	return &cfg, nil
 }
+
+// colorPattern は "#rrggbb"、"#rgb" または ANSI の色番号にマッチする
+var colorPattern = regexp.MustCompile(`^(#[0-9a-fA-F]{6}|#[0-9a-fA-F]{3}|[0-9]{1,3})$`)
+
+// validateTheme はテーマの名前、枠線、色の書式を検証する
+func validateTheme(t Theme) error {
+	if _, ok := theme.Preset(t.Preset); t.Preset != "" && !ok {
+		return fmt.Errorf("invalid theme preset: %s", t.Preset)
+	}
+	if _, ok := theme.Border(t.Border); t.Border != "" && !ok {
+		return fmt.Errorf("invalid theme border: %s", t.Border)
+	}
+	for name, c := range t.Colors() {
+		if c == "" {
+			continue
+		}
+		if n, err := strconv.Atoi(c); !colorPattern.MatchString(c) || err == nil && n > 255 {
+			return fmt.Errorf("invalid theme %s color: %s", name, c)
+		}
+	}
+	return nil
+}
+
+// validateQuestions はカスタム質問の設定を検証する
+// 条件で参照できるのは組み込みの項目とそれより前の質問のみ
+func validateQuestions(questions []Question) error {
+	var ids []string
+	for _, q := range questions {
+		switch {
+		case !questionIDPattern.MatchString(q.ID):
+			return fmt.Errorf("invalid question id: %q", q.ID)
+		case slices.Contains(ids, q.ID) || slices.Contains(BuiltinFields, q.ID) || q.ID == "type_select" || q.ID == "confirm":
+			return fmt.Errorf("duplicate question id: %s", q.ID)
+		case !slices.Contains(allowedQuestionKinds, q.GetKind()):
+			return fmt.Errorf("invalid kind for question %s: %s", q.ID, q.Kind)
+		}
+
+		hasChoices := q.GetKind() == QuestionKindSelect || q.GetKind() == QuestionKindMultiselect
+		if hasChoices && len(q.Choices) == 0 {
+			return fmt.Errorf("question %s needs choices", q.ID)
+		}
+		if !hasChoices && len(q.Choices) > 0 {
+			return fmt.Errorf("choices are only allowed for select and multiselect: %s", q.ID)
+		}
+		if q.When != nil && !slices.Contains(BuiltinFields, q.When.Field) && !slices.Contains(ids, q.When.Field) {
+			return fmt.Errorf("question %s depends on an unknown or later field: %s", q.ID, q.When.Field)
+		}
+		ids = append(ids, q.ID)
+	}
+	return nil
+}
@@ -0,0 +1,344 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// loadTestConfig は設定を一時ファイルに書き出して読み込む
+func loadTestConfig(t *testing.T, src string) (*Config, error) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	return LoadConfig(path)
+}
+
+func TestCoAuthor_UnmarshalText(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     string
+		want    []CoAuthor
+		wantErr string
+	}{
+		{
+			name: "[正常系] 名前とメールアドレス",
+			src:  "co_authors:\n  - Alice <alice@example.com>\n  - '  Bob Smith  <bob@example.com> '\n",
+			want: []CoAuthor{
+				{Name: "Alice", Email: "alice@example.com"},
+				{Name: "Bob Smith", Email: "bob@example.com"},
+			},
+		},
+		{
+			name:    "[異常系] メールアドレスがない",
+			src:     "co_authors:\n  - Alice\n",
+			wantErr: `invalid co-author "Alice", must be 'Name <email>'`,
+		},
+		{
+			name:    "[異常系] 名前がない",
+			src:     "co_authors:\n  - <alice@example.com>\n",
+			wantErr: `invalid co-author "<alice@example.com>", must be 'Name <email>'`,
+		},
+		{
+			name:    "[異常系] 空のメールアドレス",
+			src:     "co_authors:\n  - Alice <>\n",
+			wantErr: `invalid co-author "Alice <>", must be 'Name <email>'`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := loadTestConfig(t, tt.src)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("LoadConfig() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadConfig() error = %v", err)
+			}
+			if diff := cmp.Diff(tt.want, cfg.CoAuthors); diff != "" {
+				t.Errorf("CoAuthors mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRule_UnmarshalYAML(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     string
+		want    Header
+		wantErr string
+	}{
+		{
+			name: "[正常系] 値のみの場合はエラーとして扱う",
+			src:  "header:\n  max_length: 50\n  subject_case: lower\n",
+			want: Header{
+				MaxLength:   &Rule[int]{Value: 50, Level: RuleLevelError},
+				SubjectCase: &Rule[Case]{Value: CaseLower, Level: RuleLevelError},
+			},
+		},
+		{
+			name: "[正常系] 値と警告レベル",
+			src:  "header:\n  max_length:\n    value: 50\n    level: warning\n  forbidden_words:\n    value: [wip, tmp]\n    level: warning\n",
+			want: Header{
+				MaxLength:      &Rule[int]{Value: 50, Level: RuleLevelWarning},
+				ForbiddenWords: &Rule[[]string]{Value: []string{"wip", "tmp"}, Level: RuleLevelWarning},
+			},
+		},
+		{
+			name: "[正常系] レベルを省略した場合はエラーとして扱う",
+			src:  "header:\n  no_trailing_period:\n    value: true\n",
+			want: Header{
+				NoTrailingPeriod: &Rule[bool]{Value: true, Level: RuleLevelError},
+			},
+		},
+		{
+			name:    "[異常系] 不明なレベル",
+			src:     "header:\n  max_length:\n    value: 50\n    level: info\n",
+			wantErr: "invalid rule level: info",
+		},
+		{
+			name:    "[異常系] 値の型が違う",
+			src:     "header:\n  max_length: long\n",
+			wantErr: "cannot unmarshal",
+		},
+		{
+			name:    "[異常系] 値の検証",
+			src:     "header:\n  subject_case:\n    value: upper\n",
+			wantErr: "invalid case: upper",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := loadTestConfig(t, tt.src)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("LoadConfig() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadConfig() error = %v", err)
+			}
+			if diff := cmp.Diff(tt.want, cfg.Header); diff != "" {
+				t.Errorf("Header mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateQuestions(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     string
+		wantIDs []string
+		wantErr string
+	}{
+		{
+			name: "[正常系] 各種類の質問と前の質問を参照する条件",
+			src: `questions:
+  - id: tested_on
+  - id: risk
+    kind: select
+    choices: [low, high]
+  - id: migrate
+    kind: confirm
+    when:
+      field: risk
+      equals: [high]
+  - id: areas
+    kind: multiselect
+    choices: [api, ui]
+    when:
+      field: type
+`,
+			wantIDs: []string{"tested_on", "risk", "migrate", "areas"},
+		},
+		{
+			name:    "[異常系] 識別子に使えない文字",
+			src:     "questions:\n  - id: tested-on\n",
+			wantErr: `invalid question id: "tested-on"`,
+		},
+		{
+			name:    "[異常系] 質問の識別子の重複",
+			src:     "questions:\n  - id: risk\n  - id: risk\n",
+			wantErr: "duplicate question id: risk",
+		},
+		{
+			name:    "[異常系] 組み込みの項目と同じ識別子",
+			src:     "questions:\n  - id: subject\n",
+			wantErr: "duplicate question id: subject",
+		},
+		{
+			name:    "[異常系] 不明な種類",
+			src:     "questions:\n  - id: risk\n    kind: radio\n",
+			wantErr: "invalid kind for question risk: radio",
+		},
+		{
+			name:    "[異常系] 選択肢のない選択",
+			src:     "questions:\n  - id: risk\n    kind: select\n",
+			wantErr: "question risk needs choices",
+		},
+		{
+			name:    "[異常系] 選択以外の選択肢",
+			src:     "questions:\n  - id: risk\n    choices: [low]\n",
+			wantErr: "choices are only allowed for select and multiselect: risk",
+		},
+		{
+			name:    "[異常系] 後の質問を参照する条件",
+			src:     "questions:\n  - id: migrate\n    when:\n      field: risk\n  - id: risk\n",
+			wantErr: "question migrate depends on an unknown or later field: risk",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := loadTestConfig(t, tt.src)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("LoadConfig() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadConfig() error = %v", err)
+			}
+			var ids []string
+			for _, q := range cfg.Questions {
+				ids = append(ids, q.ID)
+			}
+			if diff := cmp.Diff(tt.wantIDs, ids); diff != "" {
+				t.Errorf("Questions mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestKeys_Actions(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     string
+		want    map[string][]string
+		wantErr string
+	}{
+		{
+			name: "[正常系] 指定しない操作は既定のキーのまま",
+			src:  "keys:\n  back: [ctrl+b, esc]\n  help: ['?']\n",
+			want: map[string][]string{
+				"back": {"ctrl+b", "esc"},
+				"help": {"?"},
+			},
+		},
+		{
+			name:    "[異常系] 空のリスト",
+			src:     "keys:\n  quit: []\n",
+			wantErr: "invalid keys for quit: at least one key is required",
+		},
+		{
+			name:    "[異常系] 空のキー",
+			src:     "keys:\n  edit: [e, '']\n",
+			wantErr: "invalid keys for edit: empty key",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := loadTestConfig(t, tt.src)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("LoadConfig() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadConfig() error = %v", err)
+			}
+			got := map[string][]string{}
+			for _, a := range cfg.Keys.Actions() {
+				if a.Keys != nil {
+					got[a.Name] = a.Keys
+				}
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Actions() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateTheme(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     string
+		want    Theme
+		wantErr string
+	}{
+		{
+			name: "[正常系] プリセットと枠線、色の上書き",
+			src:  "theme:\n  preset: dark\n  border: double\n  accent: '#ff8800'\n  muted: '#888'\n  error: '196'\n",
+			want: Theme{Preset: "dark", Border: "double", Accent: "#ff8800", Muted: "#888", Error: "196"},
+		},
+		{
+			name: "[正常系] 見出し・説明・選択されていないボタンの色の上書き",
+			src:  "theme:\n  group: '#7aa2f7'\n  description: '8'\n  button_idle_bg: '#222'\n",
+			want: Theme{Group: "#7aa2f7", Description: "8", ButtonIdleBg: "#222"},
+		},
+		{
+			name:    "[異常系] 不明なプリセット",
+			src:     "theme:\n  preset: solarized\n",
+			wantErr: "invalid theme preset: solarized",
+		},
+		{
+			name:    "[異常系] 不明な枠線",
+			src:     "theme:\n  border: dotted\n",
+			wantErr: "invalid theme border: dotted",
+		},
+		{
+			name:    "[異常系] 色の名前",
+			src:     "theme:\n  accent: orange\n",
+			wantErr: "invalid theme accent color: orange",
+		},
+		{
+			name:    "[異常系] 範囲外のANSIの色番号",
+			src:     "theme:\n  warning: '256'\n",
+			wantErr: "invalid theme warning color: 256",
+		},
+		{
+			name:    "[異常系] 桁の足りない色コード",
+			src:     "theme:\n  button_bg: '#12345'\n",
+			wantErr: "invalid theme button_bg color: #12345",
+		},
+		{
+			name:    "[異常系] 選択されていないボタンの色の名前",
+			src:     "theme:\n  button_idle_bg: gray\n",
+			wantErr: "invalid theme button_idle_bg color: gray",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := loadTestConfig(t, tt.src)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("LoadConfig() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadConfig() error = %v", err)
+			}
+			if diff := cmp.Diff(tt.want, cfg.Theme); diff != "" {
+				t.Errorf("Theme mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
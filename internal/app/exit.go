@@ -0,0 +1,46 @@
+package app
+
+import "github.com/cffnpwr/git-cz-go/internal/model"
+
+// Exit codes returned by the git-cz command
+const (
+	ExitCodeError      = 1   // Unexpected error (config, terminal, ...)
+	ExitCodeValidation = 2   // The message does not satisfy the config
+	ExitCodeGit        = 3   // Creating the commit failed
+	ExitCodeCancelled  = 130 // The user quit the wizard without committing
+)
+
+// ExitError is an error that terminates the command with a specific exit code
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
+// errCancelled はウィザードが中断されたことを表す
+type errCancelled struct{}
+
+func (errCancelled) Error() string {
+	return "commit cancelled, nothing was committed"
+}
+
+// outcomeError はウィザードの結果を終了コード付きのエラーに変換する
+func outcomeError(o model.Outcome) error {
+	switch o.Kind {
+	case model.OutcomeCommitted, model.OutcomeConfirmed:
+		return nil
+	case model.OutcomeValidationFailed:
+		return &ExitError{Code: ExitCodeValidation, Err: o.Err}
+	case model.OutcomeGitError:
+		return &ExitError{Code: ExitCodeGit, Err: o.Err}
+	default:
+		return &ExitError{Code: ExitCodeCancelled, Err: errCancelled{}}
+	}
+}
@@ -0,0 +1,69 @@
+package app
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/cffnpwr/git-cz-go/internal/model"
+)
+
+func TestOutcomeError(t *testing.T) {
+	cause := errors.New("cause")
+
+	tests := []struct {
+		name     string
+		outcome  model.Outcome
+		wantCode int
+		wantErr  error
+	}{
+		{
+			name:    "[正常系] コミットした場合はエラーなし",
+			outcome: model.Outcome{Kind: model.OutcomeCommitted, Hash: "abc123"},
+		},
+		{
+			name:    "[正常系] ドライランで確定した場合はエラーなし",
+			outcome: model.Outcome{Kind: model.OutcomeConfirmed},
+		},
+		{
+			name:     "[異常系] 検証エラー",
+			outcome:  model.Outcome{Kind: model.OutcomeValidationFailed, Err: cause},
+			wantCode: ExitCodeValidation,
+			wantErr:  cause,
+		},
+		{
+			name:     "[異常系] コミットエラー",
+			outcome:  model.Outcome{Kind: model.OutcomeGitError, Err: cause},
+			wantCode: ExitCodeGit,
+			wantErr:  cause,
+		},
+		{
+			name:     "[異常系] 中断",
+			outcome:  model.Outcome{Kind: model.OutcomeCancelled},
+			wantCode: ExitCodeCancelled,
+			wantErr:  errCancelled{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := outcomeError(tt.outcome)
+			if tt.wantCode == 0 {
+				if err != nil {
+					t.Errorf("outcomeError() error = %v, want nil", err)
+				}
+				return
+			}
+
+			var exitErr *ExitError
+			if !errors.As(err, &exitErr) {
+				t.Fatalf("outcomeError() error = %v, want *ExitError", err)
+			}
+			if exitErr.Code != tt.wantCode {
+				t.Errorf("ExitError.Code = %d, want %d", exitErr.Code, tt.wantCode)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("outcomeError() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
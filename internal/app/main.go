@@ -1,27 +1,136 @@
 package app
 
 import (
+	"errors"
+	"fmt"
+	"io"
 	"os"
 
 	"github.com/cffnpwr/git-cz-go/config"
+	"github.com/cffnpwr/git-cz-go/internal/interface/repo"
 	"github.com/cffnpwr/git-cz-go/internal/model"
 	"github.com/cffnpwr/git-cz-go/internal/repo/git"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 )
 
-func Run(cfg *config.Config) error {
+// Options controls how the commit message is collected
+type Options struct {
+	Prefill model.Prefill // Answers given before the wizard starts
+	Yes     bool          // Commit the prefilled answers without starting the wizard
+	DryRun  bool          // Print the message instead of committing
+	Output  string        // Format of the printed message (text or json)
+
+	AnswersFile string // Path of an answers document to commit without the wizard ("-" for stdin)
+}
+
+func Run(cfg *config.Config, opts Options) error {
 	wd, err := os.Getwd()
 	if err != nil {
 		return err
 	}
 
+	err = opts.Prefill.Validate(cfg)
+	if err != nil {
+		return &ExitError{Code: ExitCodeValidation, Err: err}
+	}
+
 	gitRepo := git.NewGitRepository(wd)
-	m, err := model.NewModel(cfg, gitRepo)
+	if opts.AnswersFile != "" {
+		data, err := loadAnswers(opts.AnswersFile)
+		if err != nil {
+			return err
+		}
+		return commitNonInteractive(os.Stdout, cfg, gitRepo, data, opts)
+	}
+	if opts.Yes {
+		return commitNonInteractive(os.Stdout, cfg, gitRepo, opts.Prefill.Data, opts)
+	}
+
+	// 標準出力をパイプで使えるようにTUIは標準エラー出力に描画する
+	// 色の判定も標準エラー出力で行うため、スタイルを作る前に renderer を差し替える
+	renderer := lipgloss.NewRenderer(os.Stderr)
+	lipgloss.SetDefaultRenderer(renderer)
+	m, err := model.NewModel(cfg, gitRepo, renderer)
+	if err != nil {
+		return err
+	}
+	m = m.SetPrefill(opts.Prefill).SetDryRun(opts.DryRun)
+
+	programOpts := []tea.ProgramOption{tea.WithOutput(os.Stderr)}
+	if cfg.AltScreen {
+		programOpts = append(programOpts, tea.WithAltScreen())
+	}
+	if cfg.Mouse {
+		programOpts = append(programOpts, tea.WithMouseCellMotion())
+	}
+	p := tea.NewProgram(m, programOpts...)
+	final, err := p.Run()
 	if err != nil {
 		return err
 	}
 
-	p := tea.NewProgram(&m)
-	p.Run()
+	result, ok := final.(model.Model)
+	if !ok {
+		return &ExitError{Code: ExitCodeCancelled, Err: errCancelled{}}
+	}
+	if cfg.AltScreen && result.GetOutcome().Kind == model.OutcomeCommitted {
+		// 代替画面は終了時に消えるため概要を表示し直す
+		fmt.Fprint(os.Stderr, result.View())
+	}
+
+	outcome := result.GetOutcome()
+	if outcome.Kind == model.OutcomeConfirmed {
+		return printMessage(os.Stdout, opts.Output, result.GetCommitData(), result.GetCommitMessage())
+	}
+	return outcomeError(outcome)
+}
+
+// loadAnswers は回答ファイルあるいは標準入力からコミットデータを読み込む
+func loadAnswers(path string) (model.CommitData, error) {
+	if path == "-" {
+		return model.LoadCommitData(os.Stdin)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return model.CommitData{}, err
+	}
+	defer f.Close()
+
+	return model.LoadCommitData(f)
+}
+
+// commitNonInteractive はウィザードを起動せずにコミットする。ドライランではメッセージを w に出力する
+func commitNonInteractive(w io.Writer, cfg *config.Config, gitRepo repo.GitRepository, data model.CommitData, opts Options) error {
+	if data.Type == "" || data.Subject == "" {
+		return &ExitError{Code: ExitCodeValidation, Err: errors.New("type and subject are required to commit without the wizard")}
+	}
+
+	data, err := data.ApplyDefaults(cfg, gitRepo)
+	if err != nil {
+		return &ExitError{Code: ExitCodeValidation, Err: err}
+	}
+
+	err = data.Validate(cfg)
+	if err != nil {
+		return &ExitError{Code: ExitCodeValidation, Err: err}
+	}
+	for _, w := range data.Warnings(cfg) {
+		fmt.Fprintln(os.Stderr, "warning: "+w.String())
+	}
+
+	message, err := data.FormatMessage(cfg)
+	if err != nil {
+		return &ExitError{Code: ExitCodeValidation, Err: err}
+	}
+	if opts.DryRun {
+		return printMessage(w, opts.Output, data, message)
+	}
+
+	_, err = gitRepo.Commit(message)
+	if err != nil {
+		return &ExitError{Code: ExitCodeGit, Err: err}
+	}
 	return nil
 }
@@ -0,0 +1,231 @@
+package app
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/cffnpwr/git-cz-go/config"
+	"github.com/cffnpwr/git-cz-go/internal/mock/repo"
+	"github.com/cffnpwr/git-cz-go/internal/model"
+	"github.com/google/go-cmp/cmp"
+	"go.uber.org/mock/gomock"
+)
+
+func createTestConfig() *config.Config {
+	return &config.Config{
+		Types: []config.TypeValue{
+			{Value: "feat", Name: "feat"},
+			{Value: "fix", Name: "fix"},
+		},
+		TicketNumber: config.TicketNumber{
+			Enable:       true,
+			Required:     true,
+			Prefix:       "#",
+			MatchPattern: (*config.Regexp)(regexp.MustCompile(`^\d+$`)),
+			FromBranchName: config.FromBranchName{
+				Enable:        true,
+				ExtractRegexp: (*config.Regexp)(regexp.MustCompile(`^\w+/(?P<ticket_number>\d+)-`)),
+			},
+		},
+	}
+}
+
+func TestCommitNonInteractive(t *testing.T) {
+	tests := []struct {
+		name       string
+		data       model.CommitData
+		opts       Options
+		mockSetup  func(*repo.MockGitRepository)
+		wantOutput string
+		wantCode   int
+	}{
+		{
+			name: "[正常系] 回答をそのままコミット",
+			data: model.CommitData{Type: "feat", TicketNumber: "12", Subject: "add form"},
+			opts: Options{Yes: true},
+			mockSetup: func(mockRepo *repo.MockGitRepository) {
+				mockRepo.EXPECT().Commit("feat: #12 add form").Return("abc123", nil)
+			},
+		},
+		{
+			name: "[正常系] チケット番号をブランチ名から補う",
+			data: model.CommitData{Type: "fix", Subject: "fix bug"},
+			opts: Options{Yes: true},
+			mockSetup: func(mockRepo *repo.MockGitRepository) {
+				mockRepo.EXPECT().GetCurrentBranch().Return("fix/34-login", nil)
+				mockRepo.EXPECT().Commit("fix: #34 fix bug").Return("abc123", nil)
+			},
+		},
+		{
+			name:       "[正常系] ドライランではコミットせずにメッセージを出力",
+			data:       model.CommitData{Type: "feat", TicketNumber: "12", Subject: "add form"},
+			opts:       Options{Yes: true, DryRun: true},
+			wantOutput: "feat: #12 add form\n",
+		},
+		{
+			name: "[正常系] ドライランでJSON形式で出力",
+			data: model.CommitData{Type: "fix", TicketNumber: "#12", Subject: "fix bug", CoAuthors: []string{"Alice <alice@example.com>"}},
+			opts: Options{Yes: true, DryRun: true, Output: OutputJSON},
+			wantOutput: `{
+  "type": "fix",
+  "scope": "",
+  "ticket_number": "#12",
+  "subject": "fix bug",
+  "body": "",
+  "breaking_changes": "",
+  "footer": "",
+  "co_authors": [
+    "Alice <alice@example.com>"
+  ],
+  "is_breaking": false,
+  "custom": null,
+  "message": "fix: #12 fix bug\n\nCo-authored-by: Alice <alice@example.com>"
+}
+`,
+		},
+		{
+			name:     "[異常系] タイプが無い",
+			data:     model.CommitData{Subject: "add form"},
+			opts:     Options{Yes: true},
+			wantCode: ExitCodeValidation,
+		},
+		{
+			name:     "[異常系] 未知のタイプ",
+			data:     model.CommitData{Type: "perf", TicketNumber: "12", Subject: "speed up"},
+			opts:     Options{Yes: true},
+			wantCode: ExitCodeValidation,
+		},
+		{
+			name:     "[異常系] 設定を満たさない",
+			data:     model.CommitData{Type: "feat", TicketNumber: "ABC", Subject: "add form"},
+			opts:     Options{Yes: true},
+			wantCode: ExitCodeValidation,
+		},
+		{
+			name: "[異常系] コミットエラー",
+			data: model.CommitData{Type: "feat", TicketNumber: "12", Subject: "add form"},
+			opts: Options{Yes: true},
+			mockSetup: func(mockRepo *repo.MockGitRepository) {
+				mockRepo.EXPECT().Commit("feat: #12 add form").Return("", errors.New("nothing to commit"))
+			},
+			wantCode: ExitCodeGit,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := repo.NewMockGitRepository(ctrl)
+			if tt.mockSetup != nil {
+				tt.mockSetup(mockRepo)
+			}
+
+			var out bytes.Buffer
+			err := commitNonInteractive(&out, createTestConfig(), mockRepo, tt.data, tt.opts)
+			if tt.wantCode == 0 {
+				if err != nil {
+					t.Errorf("commitNonInteractive() error = %v, want nil", err)
+				}
+				if diff := cmp.Diff(tt.wantOutput, out.String()); diff != "" {
+					t.Errorf("output mismatch (-want +got):\n%s", diff)
+				}
+				return
+			}
+
+			var exitErr *ExitError
+			if !errors.As(err, &exitErr) {
+				t.Fatalf("commitNonInteractive() error = %v, want *ExitError", err)
+			}
+			if exitErr.Code != tt.wantCode {
+				t.Errorf("ExitError.Code = %d, want %d", exitErr.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestCommitFromFile(t *testing.T) {
+	tests := []struct {
+		name       string
+		content    string
+		noFile     bool
+		wantCommit string
+		wantCode   int
+		wantErr    bool
+	}{
+		{
+			name:       "[正常系] YAML形式の回答ファイル",
+			content:    "type: feat\nticket_number: \"12\"\nsubject: add form\nco_authors:\n  - Alice <alice@example.com>\n",
+			wantCommit: "feat: #12 add form\n\nCo-authored-by: Alice <alice@example.com>",
+		},
+		{
+			name:       "[正常系] JSON形式の回答ファイル",
+			content:    `{"type": "fix", "ticket_number": "#34", "subject": "fix bug"}`,
+			wantCommit: "fix: #34 fix bug",
+		},
+		{
+			name:     "[異常系] 共同作成者の形式が不正",
+			content:  "type: feat\nticket_number: \"12\"\nsubject: add form\nco_authors:\n  - alice\n",
+			wantCode: ExitCodeValidation,
+		},
+		{
+			name:    "[異常系] 未知のフィールド",
+			content: "type: feat\nsummary: add form\n",
+			wantErr: true,
+		},
+		{
+			name:    "[異常系] ファイルが存在しない",
+			noFile:  true,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := repo.NewMockGitRepository(ctrl)
+			if tt.wantCommit != "" {
+				mockRepo.EXPECT().Commit(tt.wantCommit).Return("abc123", nil)
+			}
+
+			path := filepath.Join(t.TempDir(), "answers.yaml")
+			if !tt.noFile {
+				if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+					t.Fatalf("WriteFile() error = %v", err)
+				}
+			}
+
+			data, err := loadAnswers(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("loadAnswers() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			err = commitNonInteractive(io.Discard, createTestConfig(), mockRepo, data, Options{AnswersFile: path})
+			if tt.wantCode == 0 {
+				if err != nil {
+					t.Errorf("commitNonInteractive() error = %v, want nil", err)
+				}
+				return
+			}
+
+			var exitErr *ExitError
+			if !errors.As(err, &exitErr) {
+				t.Fatalf("commitNonInteractive() error = %v, want *ExitError", err)
+			}
+			if exitErr.Code != tt.wantCode {
+				t.Errorf("ExitError.Code = %d, want %d", exitErr.Code, tt.wantCode)
+			}
+		})
+	}
+}
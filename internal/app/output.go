@@ -0,0 +1,41 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/cffnpwr/git-cz-go/internal/model"
+)
+
+const (
+	OutputText = "text"
+	OutputJSON = "json"
+)
+
+// OutputFormats lists the formats accepted by printMessage
+var OutputFormats = []string{OutputText, OutputJSON}
+
+type jsonOutput struct {
+	model.CommitData
+	Message string `json:"message"`
+}
+
+// printMessage はコミットせずにメッセージを指定された形式で出力する
+func printMessage(w io.Writer, format string, data model.CommitData, message string) error {
+	switch format {
+	case OutputJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		enc.SetEscapeHTML(false)
+		return enc.Encode(jsonOutput{
+			CommitData: data,
+			Message:    message,
+		})
+	case OutputText, "":
+		_, err := fmt.Fprintln(w, message)
+		return err
+	default:
+		return fmt.Errorf("invalid output format: %s", format)
+	}
+}
@@ -0,0 +1,48 @@
+package app
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cffnpwr/git-cz-go/internal/model"
+)
+
+func TestPrintMessage(t *testing.T) {
+	data := model.CommitData{Type: "feat", Subject: "add form"}
+
+	tests := []struct {
+		name    string
+		format  string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "[正常系] 形式の指定が無い場合はテキスト",
+			format: "",
+			want:   "feat: add form\n",
+		},
+		{
+			name:   "[正常系] テキスト形式",
+			format: OutputText,
+			want:   "feat: add form\n",
+		},
+		{
+			name:    "[異常系] 未知の形式",
+			format:  "xml",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			err := printMessage(&out, tt.format, data, "feat: add form")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("printMessage() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := out.String(); got != tt.want {
+				t.Errorf("printMessage() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -8,5 +8,6 @@ type GitConfigReader interface {
 	LoadConfig(repoPath string) error
 	GetUserName() (string, error)
 	GetUserEmail() (string, error)
+	GetEditor() (string, error)
 	CreateSignature() (*object.Signature, error)
 }
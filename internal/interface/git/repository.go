@@ -1,10 +1,16 @@
 package git
 
-import "github.com/go-git/go-git/v5/plumbing"
+import (
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
 
 //go:generate mockgen -source=repository.go -destination=../../mock/git/repository.go -package=git
 
 type GitRepository interface {
 	Head() (*plumbing.Reference, error)
 	Worktree() (GitWorktree, error)
+	Log(o *git.LogOptions) (object.CommitIter, error)
+	CommitObject(h plumbing.Hash) (*object.Commit, error)
 }
@@ -1,8 +1,20 @@
 package repo
 
+import "github.com/go-git/go-git/v5/plumbing/object"
+
 //go:generate mockgen -source=git.go -destination=../../mock/repo/git.go -package=repo
 
+// CommitStats summarizes the changes introduced by a commit
+type CommitStats struct {
+	FilesChanged int
+	Insertions   int
+	Deletions    int
+}
+
 type GitRepository interface {
 	GetCurrentBranch() (string, error)
-	Commit(message string) error
+	GetRecentAuthors(limit int) ([]object.Signature, error)
+	GetCommitStats(hash string) (CommitStats, error)
+	GetEditor() string
+	Commit(message string) (string, error)
 }
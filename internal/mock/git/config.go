@@ -55,6 +55,21 @@ func (mr *MockGitConfigReaderMockRecorder) CreateSignature() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSignature", reflect.TypeOf((*MockGitConfigReader)(nil).CreateSignature))
 }
 
+// GetEditor mocks base method.
+func (m *MockGitConfigReader) GetEditor() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEditor")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEditor indicates an expected call of GetEditor.
+func (mr *MockGitConfigReaderMockRecorder) GetEditor() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEditor", reflect.TypeOf((*MockGitConfigReader)(nil).GetEditor))
+}
+
 // GetUserEmail mocks base method.
 func (m *MockGitConfigReader) GetUserEmail() (string, error) {
 	m.ctrl.T.Helper()
@@ -13,7 +13,9 @@ import (
 	reflect "reflect"
 
 	git "github.com/cffnpwr/git-cz-go/internal/interface/git"
+	git0 "github.com/go-git/go-git/v5"
 	plumbing "github.com/go-git/go-git/v5/plumbing"
+	object "github.com/go-git/go-git/v5/plumbing/object"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -41,6 +43,21 @@ func (m *MockGitRepository) EXPECT() *MockGitRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CommitObject mocks base method.
+func (m *MockGitRepository) CommitObject(h plumbing.Hash) (*object.Commit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CommitObject", h)
+	ret0, _ := ret[0].(*object.Commit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CommitObject indicates an expected call of CommitObject.
+func (mr *MockGitRepositoryMockRecorder) CommitObject(h any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommitObject", reflect.TypeOf((*MockGitRepository)(nil).CommitObject), h)
+}
+
 // Head mocks base method.
 func (m *MockGitRepository) Head() (*plumbing.Reference, error) {
 	m.ctrl.T.Helper()
@@ -56,6 +73,21 @@ func (mr *MockGitRepositoryMockRecorder) Head() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Head", reflect.TypeOf((*MockGitRepository)(nil).Head))
 }
 
+// Log mocks base method.
+func (m *MockGitRepository) Log(o *git0.LogOptions) (object.CommitIter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Log", o)
+	ret0, _ := ret[0].(object.CommitIter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Log indicates an expected call of Log.
+func (mr *MockGitRepositoryMockRecorder) Log(o any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Log", reflect.TypeOf((*MockGitRepository)(nil).Log), o)
+}
+
 // Worktree mocks base method.
 func (m *MockGitRepository) Worktree() (git.GitWorktree, error) {
 	m.ctrl.T.Helper()
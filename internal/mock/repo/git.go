@@ -12,6 +12,8 @@ package repo
 import (
 	reflect "reflect"
 
+	repo "github.com/cffnpwr/git-cz-go/internal/interface/repo"
+	object "github.com/go-git/go-git/v5/plumbing/object"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -40,11 +42,12 @@ func (m *MockGitRepository) EXPECT() *MockGitRepositoryMockRecorder {
 }
 
 // Commit mocks base method.
-func (m *MockGitRepository) Commit(message string) error {
+func (m *MockGitRepository) Commit(message string) (string, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Commit", message)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // Commit indicates an expected call of Commit.
@@ -53,6 +56,21 @@ func (mr *MockGitRepositoryMockRecorder) Commit(message any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Commit", reflect.TypeOf((*MockGitRepository)(nil).Commit), message)
 }
 
+// GetCommitStats mocks base method.
+func (m *MockGitRepository) GetCommitStats(hash string) (repo.CommitStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCommitStats", hash)
+	ret0, _ := ret[0].(repo.CommitStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCommitStats indicates an expected call of GetCommitStats.
+func (mr *MockGitRepositoryMockRecorder) GetCommitStats(hash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommitStats", reflect.TypeOf((*MockGitRepository)(nil).GetCommitStats), hash)
+}
+
 // GetCurrentBranch mocks base method.
 func (m *MockGitRepository) GetCurrentBranch() (string, error) {
 	m.ctrl.T.Helper()
@@ -67,3 +85,32 @@ func (mr *MockGitRepositoryMockRecorder) GetCurrentBranch() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentBranch", reflect.TypeOf((*MockGitRepository)(nil).GetCurrentBranch))
 }
+
+// GetEditor mocks base method.
+func (m *MockGitRepository) GetEditor() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEditor")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetEditor indicates an expected call of GetEditor.
+func (mr *MockGitRepositoryMockRecorder) GetEditor() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEditor", reflect.TypeOf((*MockGitRepository)(nil).GetEditor))
+}
+
+// GetRecentAuthors mocks base method.
+func (m *MockGitRepository) GetRecentAuthors(limit int) ([]object.Signature, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecentAuthors", limit)
+	ret0, _ := ret[0].([]object.Signature)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecentAuthors indicates an expected call of GetRecentAuthors.
+func (mr *MockGitRepositoryMockRecorder) GetRecentAuthors(limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecentAuthors", reflect.TypeOf((*MockGitRepository)(nil).GetRecentAuthors), limit)
+}
@@ -0,0 +1,141 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/cffnpwr/git-cz-go/config"
+	"github.com/cffnpwr/git-cz-go/internal/interface/repo"
+	"gopkg.in/yaml.v3"
+)
+
+// LoadCommitData reads a YAML or JSON document shaped like CommitData
+func LoadCommitData(r io.Reader) (CommitData, error) {
+	var cd CommitData
+	dec := yaml.NewDecoder(r)
+	dec.KnownFields(true)
+	err := dec.Decode(&cd)
+	if errors.Is(err, io.EOF) {
+		return CommitData{}, errors.New("answers document is empty")
+	}
+	if err != nil {
+		return CommitData{}, fmt.Errorf("failed to parse answers: %w", err)
+	}
+	return cd, nil
+}
+
+// ApplyDefaults fills in the values the wizard would have derived from the config and the repository
+func (cd CommitData) ApplyDefaults(cfg *config.Config, gitRepo repo.GitRepository) (CommitData, error) {
+	if cd.Type != "" {
+		t, err := ResolveType(cfg, cd.Type)
+		if err != nil {
+			return CommitData{}, err
+		}
+		cd.Type = t
+	}
+	cd = cd.applyTypeDefaults(cfg)
+
+	if cfg.TicketNumber.Enable && !isSkippedForType(cfg, cd.Type, StageTicketNumber) && strings.TrimSpace(cd.TicketNumber) == "" {
+		cd.TicketNumber = extractTicketFromBranch(cfg.TicketNumber, gitRepo)
+	}
+	cd.TicketNumber = FormatTicketNumber(cfg, cd.TicketNumber)
+
+	if cd.BreakingChanges != "" {
+		cd.IsBreaking = true
+	}
+	return cd, nil
+}
+
+// AnswersSchema returns a JSON schema describing the document read by LoadCommitData
+func AnswersSchema(cfg *config.Config) map[string]any {
+	var types []string
+	for _, t := range cfg.Types {
+		types = append(types, t.Value)
+	}
+	for _, t := range cfg.Types {
+		if name := t.TypeName(); name != "" && !slices.Contains(types, name) {
+			types = append(types, name)
+		}
+	}
+
+	str := func(description string) map[string]any {
+		return map[string]any{"type": "string", "description": description}
+	}
+	ticket := str("Ticket number; the configured prefix is added when missing and it is extracted from the branch name when empty")
+	if cfg.TicketNumber.MatchPattern != nil {
+		ticket["pattern"] = (*regexp.Regexp)(cfg.TicketNumber.MatchPattern).String()
+	}
+
+	required := []string{"type", "subject"}
+	if cfg.TicketNumber.Enable && cfg.TicketNumber.Required && !cfg.TicketNumber.FromBranchName.Enable {
+		required = append(required, "ticket_number")
+	}
+
+	custom := map[string]any{}
+	for _, q := range cfg.Questions {
+		custom[q.ID] = questionSchema(q)
+	}
+
+	return map[string]any{
+		"$schema":              "https://json-schema.org/draft/2020-12/schema",
+		"title":                "git-cz answers",
+		"type":                 "object",
+		"additionalProperties": false,
+		"required":             required,
+		"properties": map[string]any{
+			"type": map[string]any{
+				"type":        "string",
+				"description": "Commit type, either the configured value or the bare type name",
+				"enum":        types,
+			},
+			"scope":            str("Scope of the change"),
+			"ticket_number":    ticket,
+			"subject":          map[string]any{"type": "string", "description": "Commit subject", "minLength": 1},
+			"body":             str("Commit body"),
+			"breaking_changes": str("Description of the breaking changes; marks the commit as breaking"),
+			"footer":           str("Footer lines written as 'word: content' or 'word #content'"),
+			"co_authors": map[string]any{
+				"type":        "array",
+				"description": "Co-authors written as 'Name <email>'",
+				"items":       map[string]any{"type": "string", "pattern": "^.+ <[^>]+>$"},
+			},
+			"is_breaking": map[string]any{"type": "boolean", "description": "Mark the commit as breaking without a description"},
+			"custom": map[string]any{
+				"type":                 "object",
+				"description":          "Answers to the custom questions keyed by their id",
+				"additionalProperties": false,
+				"properties":           custom,
+			},
+		},
+	}
+}
+
+// questionSchema はカスタム質問の回答のスキーマを返す
+func questionSchema(q config.Question) map[string]any {
+	description := q.Prompt
+	if description == "" {
+		description = q.ID
+	}
+
+	str := map[string]any{"type": "string"}
+	if len(q.Choices) > 0 {
+		str["enum"] = q.Choices
+	}
+	if q.Validate != nil {
+		str["pattern"] = (*regexp.Regexp)(q.Validate).String()
+	}
+
+	switch q.GetKind() {
+	case config.QuestionKindConfirm:
+		return map[string]any{"type": "boolean", "description": description}
+	case config.QuestionKindMultiselect:
+		return map[string]any{"type": "array", "description": description, "items": str}
+	default:
+		str["description"] = description
+		return str
+	}
+}
@@ -0,0 +1,121 @@
+package model
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/cffnpwr/git-cz-go/config"
+	"github.com/cffnpwr/git-cz-go/internal/mock/repo"
+	"github.com/google/go-cmp/cmp"
+	"go.uber.org/mock/gomock"
+)
+
+func TestLoadCommitData(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    CommitData
+		wantErr bool
+	}{
+		{
+			name:  "[正常系] YAML形式",
+			input: "type: feat\nsubject: add feature\nco_authors:\n  - Alice <alice@example.com>\n",
+			want: CommitData{
+				Type:      "feat",
+				Subject:   "add feature",
+				CoAuthors: []string{"Alice <alice@example.com>"},
+			},
+		},
+		{
+			name:  "[正常系] JSON形式",
+			input: `{"type": "fix", "subject": "fix bug", "is_breaking": true}`,
+			want: CommitData{
+				Type:       "fix",
+				Subject:    "fix bug",
+				IsBreaking: true,
+			},
+		},
+		{
+			name:    "[異常系] 未知のフィールド",
+			input:   "type: feat\nsummary: typo\n",
+			wantErr: true,
+		},
+		{
+			name:    "[異常系] 空のドキュメント",
+			input:   "",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := LoadCommitData(strings.NewReader(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadCommitData() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("LoadCommitData() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCommitData_ApplyDefaults(t *testing.T) {
+	tests := []struct {
+		name       string
+		data       CommitData
+		branchName string
+		want       CommitData
+		wantErr    bool
+	}{
+		{
+			name:       "[正常系] ブランチ名からチケット番号を抽出",
+			data:       CommitData{Type: "feat", Subject: "add", BreakingChanges: "removed api"},
+			branchName: "feature/123-add",
+			want: CommitData{
+				Type:            "feat: :sparkles:",
+				Subject:         "add",
+				TicketNumber:    "#123",
+				BreakingChanges: "removed api",
+				IsBreaking:      true,
+			},
+		},
+		{
+			name: "[正常系] プレフィックス付きのチケット番号はそのまま",
+			data: CommitData{Type: "fix: :bug:", Subject: "fix", TicketNumber: "#45"},
+			want: CommitData{Type: "fix: :bug:", Subject: "fix", TicketNumber: "#45"},
+		},
+		{
+			name:    "[異常系] 存在しないタイプ",
+			data:    CommitData{Type: "perf", Subject: "faster"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := repo.NewMockGitRepository(ctrl)
+			if tt.branchName != "" {
+				mockRepo.EXPECT().GetCurrentBranch().Return(tt.branchName, nil)
+			}
+
+			cfg := createTestConfig()
+			cfg.TicketNumber.FromBranchName = config.FromBranchName{
+				Enable:        true,
+				ExtractRegexp: (*config.Regexp)(regexp.MustCompile(`^.+?/(?P<ticket_number>\d+)([-_]\w+)*$`)),
+			}
+
+			got, err := tt.data.ApplyDefaults(cfg, mockRepo)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ApplyDefaults() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ApplyDefaults() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
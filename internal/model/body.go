@@ -0,0 +1,305 @@
+package model
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/cffnpwr/git-cz-go/config"
+	"github.com/cffnpwr/git-cz-go/internal/util"
+	"github.com/cffnpwr/git-cz-go/pkg/theme"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+)
+
+// BodyModel は本文を入力し、入力完了時に設定された幅で折り返すモデル
+type BodyModel struct {
+	textarea   textarea.Model
+	config     config.Body
+	typeValue  config.TypeValue // 選択されたタイプのテンプレートと必須設定
+	keys       KeyMap
+	styles     Styles
+	finished   bool
+	violations []Violation
+}
+
+func NewBodyModel(prompt string, bodyCfg config.Body) BodyModel {
+	ta := textarea.New()
+	if prompt == "" {
+		prompt = defaultBodyPrompt
+	}
+	ta.Prompt = prompt + defaultPromptSeparator
+
+	return BodyModel{
+		textarea: ta,
+		config:   bodyCfg,
+		keys:     DefaultKeyMap,
+	}.SetStyles(NewStyles(lipgloss.DefaultRenderer(), theme.Default))
+}
+
+// SetKeyMap sets the keys used to submit the body and start a bullet
+func (m BodyModel) SetKeyMap(km KeyMap) BodyModel {
+	m.keys = km
+	return m
+}
+
+// SetStyles sets the styles of the prompt and the messages under the textarea
+func (m BodyModel) SetStyles(s Styles) BodyModel {
+	m.styles = s
+	m.textarea.FocusedStyle = textarea.Style{
+		Prompt: s.Prompt,
+	}
+	return m
+}
+
+// SetSize fits the textarea into the space left for the body, keeping a line for the hint and one for a violation
+func (m BodyModel) SetSize(width, height int) BodyModel {
+	m.textarea = fitTextarea(m.textarea, width, height, 2)
+	return m
+}
+
+// ShortHelp returns the keys shown in the help line, implementing help.KeyMap
+func (m BodyModel) ShortHelp() []key.Binding {
+	return []key.Binding{m.keys.Submit, m.keys.Bullet}
+}
+
+// FullHelp returns the keys shown in the full help, implementing help.KeyMap
+func (m BodyModel) FullHelp() [][]key.Binding {
+	return [][]key.Binding{m.ShortHelp()}
+}
+
+func (m BodyModel) GetPrompt() string {
+	return m.textarea.Prompt
+}
+
+func (m BodyModel) GetValue() string {
+	return m.textarea.Value()
+}
+
+func (m BodyModel) SetValue(s string) BodyModel {
+	m.textarea.SetValue(s)
+	return m
+}
+
+// SetType seeds the body with the default or template of the selected type unless the user has already written something
+func (m BodyModel) SetType(tv config.TypeValue) BodyModel {
+	value := strings.TrimSpace(m.textarea.Value())
+	if value == "" || value == strings.TrimSpace(bodySeed(m.typeValue)) {
+		m.textarea.SetValue(bodySeed(tv))
+	}
+	m.typeValue = tv
+	m.violations = nil
+	return m
+}
+
+// bodySeed はタイプの本文の既定値を、無ければテンプレートを返す
+func bodySeed(tv config.TypeValue) string {
+	if tv.Defaults.Body != "" {
+		return tv.Defaults.Body
+	}
+	return tv.BodyTemplate
+}
+
+func (m BodyModel) IsFinished() bool {
+	return m.finished
+}
+
+// Reset makes the model editable again while keeping the entered value
+func (m BodyModel) Reset() BodyModel {
+	m.finished = false
+	return m
+}
+
+func (m *BodyModel) Focus() {
+	m.textarea.Focus()
+}
+
+func (m BodyModel) Init() tea.Cmd {
+	return textarea.Blink
+}
+
+func (m BodyModel) Update(msg tea.Msg) (BodyModel, tea.Cmd) {
+	if m.finished {
+		return m, nil
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch {
+		case key.Matches(msg, m.keys.Submit):
+			value := m.textarea.Value()
+			// テンプレートのまま変更されていない場合は本文なしとする
+			if strings.TrimSpace(value) == strings.TrimSpace(m.typeValue.BodyTemplate) {
+				value = ""
+			} else if m.config.ShouldRewrap() {
+				value = m.rewrap(value)
+				m.textarea.SetValue(value)
+			}
+
+			m.violations = append(lintBodySections(m.typeValue, value), lintBody(m.config, value)...)
+			if !hasErrors(m.violations) {
+				m.textarea.SetValue(value)
+				m.finished = true
+			}
+			return m, nil
+		case key.Matches(msg, m.keys.Bullet):
+			// 行の途中で押しても行を分割せず、行末から次の項目を始める
+			m.textarea.CursorEnd()
+			m.textarea.InsertString(m.nextBullet())
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.textarea, cmd = m.textarea.Update(msg)
+	return m, cmd
+}
+
+func (m BodyModel) View() string {
+	view := m.textarea.View()
+	if m.finished {
+		return view
+	}
+
+	for _, v := range m.violations {
+		view += "\n" + m.styles.violation(v)
+	}
+	view += "\n" + m.styles.Info.Render(fmt.Sprintf("Press %s for a new bullet, %s to continue",
+		m.keys.Bullet.Help().Key, m.keys.Submit.Help().Key))
+	return view
+}
+
+// rewrap はテンプレートの見出しごとに本文を折り返す
+// 見出しの行が前の段落に連結されないようにする
+func (m BodyModel) rewrap(body string) string {
+	headings := templateHeadings(m.typeValue.BodyTemplate)
+	var chunks, current []string
+	for _, line := range strings.Split(body, "\n") {
+		h := sectionHeading(line, headings)
+		if h != "" && len(current) > 0 {
+			chunks = append(chunks, strings.Join(current, "\n"))
+			current = nil
+		}
+		current = append(current, line)
+		// 見出しのみの行は続く内容と連結しない
+		if h != "" && strings.TrimSpace(line) == h+":" {
+			chunks = append(chunks, line)
+			current = nil
+		}
+	}
+	if len(current) > 0 {
+		chunks = append(chunks, strings.Join(current, "\n"))
+	}
+
+	for i, c := range chunks {
+		chunks[i] = util.Wrap(c, m.config.GetWrapWidth())
+	}
+	return strings.Join(chunks, "\n")
+}
+
+// nextBullet はカーソルのある行の箇条書きを次の行に続けるための文字列を返す
+func (m BodyModel) nextBullet() string {
+	lines := strings.Split(m.textarea.Value(), "\n")
+	line := lines[min(m.textarea.Line(), len(lines)-1)]
+
+	match := util.BulletPattern.FindStringSubmatch(line)
+	switch {
+	case match == nil && strings.TrimSpace(line) == "":
+		return "- "
+	case match == nil:
+		return "\n- "
+	}
+
+	// 番号付きの箇条書きは番号を進める
+	indent, marker := match[1], match[2]
+	if n, err := strconv.Atoi(strings.TrimRight(marker, ".)")); err == nil {
+		marker = strconv.Itoa(n+1) + marker[len(marker)-1:]
+	}
+	return "\n" + indent + marker + " "
+}
+
+// templateHeadings はテンプレートの`:`で終わる行を見出しとして返す
+func templateHeadings(template string) []string {
+	var headings []string
+	for _, line := range strings.Split(template, "\n") {
+		if heading, ok := strings.CutSuffix(strings.TrimSpace(line), ":"); ok && heading != "" {
+			headings = append(headings, heading)
+		}
+	}
+	return headings
+}
+
+// sectionHeading は行がテンプレートの見出しで始まる場合にその見出しを返す
+func sectionHeading(line string, headings []string) string {
+	for _, h := range headings {
+		if strings.HasPrefix(strings.TrimSpace(line), h+":") {
+			return h
+		}
+	}
+	return ""
+}
+
+// lintBodySections はタイプで必須とされた本文とテンプレートの各見出しの内容を検証する
+func lintBodySections(tv config.TypeValue, body string) []Violation {
+	if !tv.BodyRequired && !slices.Contains(tv.RequiredQuestions, string(StageBody)) {
+		return nil
+	}
+
+	headings := templateHeadings(tv.BodyTemplate)
+	if len(headings) == 0 {
+		if strings.TrimSpace(body) == "" {
+			return []Violation{{Stage: StageBody, Message: "Body is required for type: " + tv.TypeName()}}
+		}
+		return nil
+	}
+
+	// 見出しの後ろ（同じ行と次の見出しまでの行）に内容があるかを調べる
+	filled := map[string]bool{}
+	current := ""
+	for _, line := range strings.Split(body, "\n") {
+		if h := sectionHeading(line, headings); h != "" {
+			current = h
+			line = strings.TrimPrefix(strings.TrimSpace(line), h+":")
+		}
+		if current != "" && strings.TrimSpace(line) != "" {
+			filled[current] = true
+		}
+	}
+
+	var violations []Violation
+	for _, h := range headings {
+		if !filled[h] {
+			violations = append(violations, Violation{Stage: StageBody, Message: fmt.Sprintf("Section %q is required", h)})
+		}
+	}
+	return violations
+}
+
+// lintBody は本文の各行の幅を検証する
+// コードブロック内の行は対象外とする
+func lintBody(bodyCfg config.Body, body string) []Violation {
+	r := bodyCfg.MaxLineLength
+	if r == nil {
+		return nil
+	}
+
+	var violations []Violation
+	inCode := false
+	for i, line := range strings.Split(body, "\n") {
+		if util.CodeFencePattern.MatchString(line) {
+			inCode = !inCode
+			continue
+		}
+		if w := runewidth.StringWidth(line); !inCode && w > r.Value {
+			violations = append(violations, Violation{
+				Stage:   StageBody,
+				Message: fmt.Sprintf("Line %d is %d columns wide, longer than %d", i+1, w, r.Value),
+				Warning: r.IsWarning(),
+			})
+		}
+	}
+	return violations
+}
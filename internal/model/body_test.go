@@ -0,0 +1,254 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/cffnpwr/git-cz-go/config"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestBodyModel_Update(t *testing.T) {
+	noRewrap := false
+	submit := tea.KeyMsg{Type: tea.KeyEnter, Alt: true}
+	bullet := tea.KeyMsg{Type: tea.KeyCtrlO}
+
+	tests := []struct {
+		name         string
+		bodyCfg      config.Body
+		keyInputs    []tea.KeyMsg
+		wantValue    string
+		wantFinished bool
+	}{
+		{
+			name:    "[正常系] 入力完了時に折り返す",
+			bodyCfg: config.Body{WrapWidth: 10},
+			keyInputs: []tea.KeyMsg{
+				{Type: tea.KeyRunes, Runes: []rune("alpha beta gamma")},
+				submit,
+			},
+			wantValue:    "alpha beta\ngamma",
+			wantFinished: true,
+		},
+		{
+			name:    "[正常系] 折り返しを無効化",
+			bodyCfg: config.Body{WrapWidth: 10, Rewrap: &noRewrap},
+			keyInputs: []tea.KeyMsg{
+				{Type: tea.KeyRunes, Runes: []rune("alpha beta gamma")},
+				submit,
+			},
+			wantValue:    "alpha beta gamma",
+			wantFinished: true,
+		},
+		{
+			name: "[正常系] 箇条書きを続ける",
+			keyInputs: []tea.KeyMsg{
+				bullet,
+				{Type: tea.KeyRunes, Runes: []rune("one")},
+				bullet,
+				{Type: tea.KeyRunes, Runes: []rune("two")},
+			},
+			wantValue: "- one\n- two",
+		},
+		{
+			name: "[正常系] 番号付きの箇条書きを続ける",
+			keyInputs: []tea.KeyMsg{
+				{Type: tea.KeyRunes, Runes: []rune("intro")},
+				bullet,
+				{Type: tea.KeyRunes, Runes: []rune("x")},
+				{Type: tea.KeyEnter},
+				{Type: tea.KeyRunes, Runes: []rune("1. one")},
+				bullet,
+			},
+			wantValue: "intro\n- x\n1. one\n2. ",
+		},
+		{
+			name: "[正常系] 行の途中からでも行末に次の項目を追加",
+			keyInputs: []tea.KeyMsg{
+				bullet,
+				{Type: tea.KeyRunes, Runes: []rune("one two")},
+				{Type: tea.KeyLeft},
+				{Type: tea.KeyLeft},
+				{Type: tea.KeyLeft},
+				bullet,
+				{Type: tea.KeyRunes, Runes: []rune("three")},
+			},
+			wantValue: "- one two\n- three",
+		},
+		{
+			name:    "[異常系] 行の幅の上限を超えると完了できない",
+			bodyCfg: config.Body{Rewrap: &noRewrap, MaxLineLength: &config.Rule[int]{Value: 10}},
+			keyInputs: []tea.KeyMsg{
+				{Type: tea.KeyRunes, Runes: []rune("日本語のメッセージ")},
+				submit,
+			},
+			wantValue:    "日本語のメッセージ",
+			wantFinished: false,
+		},
+		{
+			name:    "[正常系] 警告の場合は完了できる",
+			bodyCfg: config.Body{Rewrap: &noRewrap, MaxLineLength: &config.Rule[int]{Value: 10, Level: config.RuleLevelWarning}},
+			keyInputs: []tea.KeyMsg{
+				{Type: tea.KeyRunes, Runes: []rune("日本語のメッセージ")},
+				submit,
+			},
+			wantValue:    "日本語のメッセージ",
+			wantFinished: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := NewBodyModel("", tt.bodyCfg)
+			model.Focus()
+			for _, k := range tt.keyInputs {
+				model, _ = model.Update(k)
+			}
+
+			if diff := cmp.Diff(tt.wantValue, model.GetValue()); diff != "" {
+				t.Errorf("GetValue() mismatch (-want +got):\n%s", diff)
+			}
+			if model.IsFinished() != tt.wantFinished {
+				t.Errorf("IsFinished() = %v, want %v", model.IsFinished(), tt.wantFinished)
+			}
+		})
+	}
+}
+
+func TestLintBody(t *testing.T) {
+	bodyCfg := config.Body{MaxLineLength: &config.Rule[int]{Value: 8}}
+
+	tests := []struct {
+		name string
+		body string
+		want []Violation
+	}{
+		{
+			name: "[正常系] ```のコードブロック内の行は対象外",
+			body: "short\nthis line is long\n```\ncode that is long\n```\n全角の文字列",
+			want: []Violation{
+				{Stage: StageBody, Message: "Line 2 is 17 columns wide, longer than 8"},
+				{Stage: StageBody, Message: "Line 6 is 12 columns wide, longer than 8"},
+			},
+		},
+		{
+			name: "[正常系] ~~~のコードブロック内の行は対象外",
+			body: "~~~go\ncode that is long\n~~~\nthis line is long",
+			want: []Violation{
+				{Stage: StageBody, Message: "Line 4 is 17 columns wide, longer than 8"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, lintBody(bodyCfg, tt.body)); diff != "" {
+				t.Errorf("lintBody() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestBodyModel_SetType(t *testing.T) {
+	fixType := config.TypeValue{Value: "fix", BodyTemplate: "Cause:\nFix:\n", BodyRequired: true}
+	perfType := config.TypeValue{Value: "perf", BodyTemplate: "Benchmark:\n"}
+	submit := tea.KeyMsg{Type: tea.KeyEnter, Alt: true}
+
+	tests := []struct {
+		name         string
+		setup        func(BodyModel) BodyModel
+		wantValue    string
+		wantFinished bool
+	}{
+		{
+			name: "[正常系] テンプレートのままなら本文なし",
+			setup: func(m BodyModel) BodyModel {
+				return m.SetType(perfType)
+			},
+			wantValue:    "",
+			wantFinished: true,
+		},
+		{
+			name: "[正常系] タイプを変更するとテンプレートを差し替える",
+			setup: func(m BodyModel) BodyModel {
+				return m.SetType(fixType).SetType(perfType)
+			},
+			wantValue:    "",
+			wantFinished: true,
+		},
+		{
+			name: "[正常系] 入力済みの本文は差し替えない",
+			setup: func(m BodyModel) BodyModel {
+				return m.SetValue("already written").SetType(perfType)
+			},
+			wantValue:    "already written",
+			wantFinished: true,
+		},
+		{
+			name: "[異常系] 必須の見出しが空なら完了できない",
+			setup: func(m BodyModel) BodyModel {
+				return m.SetType(fixType)
+			},
+			wantValue:    "Cause:\nFix:\n",
+			wantFinished: false,
+		},
+		{
+			name: "[正常系] 必須の見出しを全て入力",
+			setup: func(m BodyModel) BodyModel {
+				return m.SetType(fixType).SetValue("Cause: nil map\nFix:\ninitialize the map")
+			},
+			wantValue:    "Cause: nil map\nFix:\ninitialize the map",
+			wantFinished: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := tt.setup(NewBodyModel("", config.Body{}))
+			model, _ = model.Update(submit)
+
+			if diff := cmp.Diff(tt.wantValue, model.GetValue()); diff != "" {
+				t.Errorf("GetValue() mismatch (-want +got):\n%s", diff)
+			}
+			if model.IsFinished() != tt.wantFinished {
+				t.Errorf("IsFinished() = %v, want %v", model.IsFinished(), tt.wantFinished)
+			}
+		})
+	}
+}
+
+func TestLintBodySections(t *testing.T) {
+	tests := []struct {
+		name string
+		tv   config.TypeValue
+		body string
+		want []Violation
+	}{
+		{
+			name: "[正常系] 必須でない",
+			tv:   config.TypeValue{Value: "perf: :zap:", BodyTemplate: "Benchmark:"},
+		},
+		{
+			name: "[異常系] テンプレートなしで本文が空",
+			tv:   config.TypeValue{Value: "revert: :rewind:", BodyRequired: true},
+			want: []Violation{{Stage: StageBody, Message: "Body is required for type: revert"}},
+		},
+		{
+			name: "[異常系] 一部の見出しが空",
+			tv:   config.TypeValue{Value: "fix: :bug:", BodyTemplate: "Cause:\nFix:\nImpact:", BodyRequired: true},
+			body: "Cause: typo\nFix:\nImpact:\n",
+			want: []Violation{
+				{Stage: StageBody, Message: `Section "Fix" is required`},
+				{Stage: StageBody, Message: `Section "Impact" is required`},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, lintBodySections(tt.tv, tt.body)); diff != "" {
+				t.Errorf("lintBodySections() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -2,9 +2,11 @@ package model
 
 import (
 	"github.com/cffnpwr/git-cz-go/pkg/component/confirm"
+	"github.com/cffnpwr/git-cz-go/pkg/theme"
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 )
 
 type BreakingStage string
@@ -24,6 +26,7 @@ type BreakingChangesModel struct {
 	stage     BreakingStage
 	confirm   confirm.Model
 	textinput textinput.Model
+	keys      KeyMap
 }
 
 func NewBreakingChangesModel(confirmPrompt, messagePrompt string) BreakingChangesModel {
@@ -48,9 +51,50 @@ func NewBreakingChangesModel(confirmPrompt, messagePrompt string) BreakingChange
 		stage:     BreakingStageConfirm,
 		confirm:   confirmModel,
 		textinput: textinputModel,
+		keys:      DefaultKeyMap,
 	}
 }
 
+// SetKeyMap sets the keys of the confirmation and the key submitting the message
+func (m BreakingChangesModel) SetKeyMap(km KeyMap) BreakingChangesModel {
+	m.keys = km
+	m.confirm = m.confirm.SetKeyMap(km.Confirm)
+	return m
+}
+
+// SetWidth fits the confirmation and the input into the width
+func (m BreakingChangesModel) SetWidth(w int) BreakingChangesModel {
+	m.confirm = m.confirm.SetWidth(w)
+	m.textinput = fitInput(m.textinput, w)
+	return m
+}
+
+// SetTheme sets the colors of the confirmation
+func (m BreakingChangesModel) SetTheme(t theme.Theme) BreakingChangesModel {
+	m.confirm = m.confirm.SetTheme(t)
+	return m
+}
+
+// SetRenderer sets the renderer the confirmation is styled with
+func (m BreakingChangesModel) SetRenderer(r *lipgloss.Renderer) BreakingChangesModel {
+	m.confirm = m.confirm.SetRenderer(r)
+	return m
+}
+
+// ShortHelp returns the keys of the current stage, implementing help.KeyMap
+func (m BreakingChangesModel) ShortHelp() []key.Binding {
+	if m.stage == BreakingStageInput {
+		return []key.Binding{m.keys.Submit}
+	}
+	km := m.keys.Confirm
+	return []key.Binding{km.Toggle, km.Affirmative, km.Negative, km.Select}
+}
+
+// FullHelp returns the keys of the current stage, implementing help.KeyMap
+func (m BreakingChangesModel) FullHelp() [][]key.Binding {
+	return [][]key.Binding{m.ShortHelp()}
+}
+
 func (m BreakingChangesModel) GetConfirmPrompt() string {
 	return m.confirm.Prompt
 }
@@ -74,7 +118,21 @@ func (m BreakingChangesModel) HasBreakingChanges() bool {
 	return m.confirm.IsConfirmed() && m.confirm.GetValue()
 }
 
-func (m BreakingChangesModel) Focus() {
+// SetValue restores a previous answer, highlighting Yes when there are breaking changes
+func (m BreakingChangesModel) SetValue(isBreaking bool, description string) BreakingChangesModel {
+	m.confirm = m.confirm.SetValue(isBreaking || description != "")
+	m.textinput.SetValue(description)
+	return m
+}
+
+// Reset returns to the confirm step while keeping the previous answer and description
+func (m BreakingChangesModel) Reset() BreakingChangesModel {
+	m.stage = BreakingStageConfirm
+	m.confirm = m.confirm.Reset()
+	return m
+}
+
+func (m *BreakingChangesModel) Focus() {
 	if m.stage == BreakingStageInput {
 		m.textinput.Focus()
 	}
@@ -99,7 +157,7 @@ func (m BreakingChangesModel) Update(msg tea.Msg) (BreakingChangesModel, tea.Cmd
 		return m, cmd
 	case BreakingStageInput:
 		if msg, ok := msg.(tea.KeyMsg); ok {
-			if key.Matches(msg, submitKey) {
+			if key.Matches(msg, m.keys.Submit) {
 				m.stage = BreakingStageFinished
 				return m, nil
 			}
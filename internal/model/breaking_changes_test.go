@@ -29,6 +29,7 @@ func createTestBreakingChangesModel() BreakingChangesModel {
 		stage:     BreakingStageConfirm,
 		confirm:   cm,
 		textinput: textinput.New(),
+		keys:      DefaultKeyMap,
 	}
 }
 
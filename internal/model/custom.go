@@ -0,0 +1,179 @@
+package model
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/cffnpwr/git-cz-go/config"
+)
+
+// questionStage returns the stage asking a custom question
+func questionStage(q config.Question) Stage {
+	return Stage(q.ID)
+}
+
+// setCustom はカスタム質問の回答を設定したコミットデータを返す
+func (cd CommitData) setCustom(id string, value any) CommitData {
+	cd.Custom = maps.Clone(cd.Custom)
+	if cd.Custom == nil {
+		cd.Custom = map[string]any{}
+	}
+	cd.Custom[id] = value
+	return cd
+}
+
+// activeAnswers は条件を満たすカスタム質問の回答を返す
+// 条件は前の質問から順に評価し、質問されない質問の回答は条件の評価にも使わない
+func (cd CommitData) activeAnswers(cfg *config.Config) map[string]any {
+	active := map[string]any{}
+	view := cd
+	view.Custom = active
+	for _, q := range cfg.Questions {
+		if q.When == nil || view.matches(cfg, *q.When) {
+			active[q.ID] = normalizeAnswer(q, cd.Custom[q.ID])
+		}
+	}
+	return active
+}
+
+// isQuestionAsked reports whether the condition of the custom question holds for the answers so far
+func (cd CommitData) isQuestionAsked(cfg *config.Config, id string) bool {
+	_, ok := cd.activeAnswers(cfg)[id]
+	return ok
+}
+
+// templateAnswers はテンプレートで参照するカスタム質問の回答を返す
+// 質問されなかった質問は種類に応じたゼロ値とする
+func (cd CommitData) templateAnswers(cfg *config.Config) map[string]any {
+	answers := cd.activeAnswers(cfg)
+	for _, q := range cfg.Questions {
+		if _, ok := answers[q.ID]; !ok {
+			answers[q.ID] = normalizeAnswer(q, nil)
+		}
+	}
+	return answers
+}
+
+// matches は条件で参照する回答が指定された値のいずれかに一致するかを返す
+// 値が指定されていない場合は回答されているかを返す
+func (cd CommitData) matches(cfg *config.Config, c config.Condition) bool {
+	values := cd.fieldValues(cfg, c.Field)
+	if len(c.Equals) == 0 {
+		return slices.ContainsFunc(values, func(v string) bool { return v != "" && v != "false" })
+	}
+	return slices.ContainsFunc(values, func(v string) bool { return slices.Contains(c.Equals, v) })
+}
+
+// fieldValues は組み込みの項目あるいはカスタム質問の回答を文字列の一覧として返す
+// タイプは設定された値とタイプ名のどちらでも一致する
+func (cd CommitData) fieldValues(cfg *config.Config, field string) []string {
+	switch field {
+	case "type":
+		typeValue, _ := findTypeValue(cfg, cd.Type)
+		return []string{cd.Type, typeValue.TypeName()}
+	case "breaking":
+		return []string{strconv.FormatBool(cd.IsBreaking || cd.BreakingChanges != "")}
+	case "co_authors":
+		return cd.CoAuthors
+	}
+	if slices.Contains(config.BuiltinFields, field) {
+		return []string{cd.stageValue(Stage(field))}
+	}
+	return answerStrings(cd.Custom[field])
+}
+
+// normalizeAnswer は回答ファイルから読み込んだ値なども含めて質問の種類に応じた型に揃える
+func normalizeAnswer(q config.Question, v any) any {
+	switch q.GetKind() {
+	case config.QuestionKindConfirm:
+		b, _ := v.(bool)
+		if s, ok := v.(string); ok {
+			b, _ = strconv.ParseBool(s)
+		}
+		return b
+	case config.QuestionKindMultiselect:
+		return answerStrings(v)
+	default:
+		if v == nil {
+			return ""
+		}
+		if s, ok := v.(string); ok {
+			return s
+		}
+		return fmt.Sprint(v)
+	}
+}
+
+// answerStrings は回答を文字列の一覧に変換する
+func answerStrings(v any) []string {
+	switch v := v.(type) {
+	case nil:
+		return nil
+	case []string:
+		return v
+	case []any:
+		values := make([]string, len(v))
+		for i, item := range v {
+			values[i] = fmt.Sprint(item)
+		}
+		return values
+	default:
+		return []string{fmt.Sprint(v)}
+	}
+}
+
+// lintCustom は質問されるカスタム質問の回答と、設定に無い回答を検証する
+func (cd CommitData) lintCustom(cfg *config.Config) []Violation {
+	var violations []Violation
+	active := cd.activeAnswers(cfg)
+	for _, q := range cfg.Questions {
+		if v, ok := active[q.ID]; ok {
+			violations = append(violations, lintAnswer(q, v)...)
+		}
+	}
+
+	for _, id := range slices.Sorted(maps.Keys(cd.Custom)) {
+		if !slices.ContainsFunc(cfg.Questions, func(q config.Question) bool { return q.ID == id }) {
+			violations = append(violations, Violation{Stage: Stage(id), Message: "Unknown question: " + id})
+		}
+	}
+	return violations
+}
+
+// lintAnswer は1つのカスタム質問の回答を検証する
+func lintAnswer(q config.Question, answer any) []Violation {
+	var violations []Violation
+	add := func(format string, args ...any) {
+		violations = append(violations, Violation{Stage: questionStage(q), Message: fmt.Sprintf(format, args...)})
+	}
+
+	if q.GetKind() == config.QuestionKindConfirm {
+		return nil
+	}
+	var values []string
+	for _, v := range answerStrings(answer) {
+		if strings.TrimSpace(v) != "" {
+			values = append(values, v)
+		}
+	}
+	if len(values) == 0 {
+		if q.Required {
+			add("Answer is required")
+		}
+		return violations
+	}
+
+	for _, v := range values {
+		if len(q.Choices) > 0 && !slices.Contains(q.Choices, v) {
+			add("Unknown choice: %s", v)
+		}
+		if q.Validate != nil && !(*regexp.Regexp)(q.Validate).MatchString(v) {
+			add("Answer must match %s", (*regexp.Regexp)(q.Validate).String())
+		}
+	}
+	return violations
+}
@@ -0,0 +1,94 @@
+package model
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/cffnpwr/git-cz-go/config"
+	"github.com/google/go-cmp/cmp"
+)
+
+func createCustomQuestionsConfig() *config.Config {
+	cfg := createTestConfig()
+	cfg.Questions = []config.Question{
+		{ID: "tested_on", Prompt: "Tested on", Required: true, Validate: (*config.Regexp)(regexp.MustCompile(`^\S+$`))},
+		{ID: "risk", Kind: config.QuestionKindSelect, Choices: []string{"low", "high"}, When: &config.Condition{Field: "type", Equals: []string{"feat"}}},
+		{ID: "rollback", Kind: config.QuestionKindConfirm, When: &config.Condition{Field: "risk", Equals: []string{"high"}}},
+		{ID: "reviewers", Kind: config.QuestionKindMultiselect, Choices: []string{"alice", "bob"}},
+	}
+	return cfg
+}
+
+func TestCommitData_activeAnswers(t *testing.T) {
+	tests := []struct {
+		name string
+		cd   CommitData
+		want map[string]any
+	}{
+		{
+			name: "[正常系] 条件を満たさない質問は含まない",
+			cd:   CommitData{Type: "fix: :bug:", Custom: map[string]any{"tested_on": "linux", "risk": "high", "rollback": true}},
+			want: map[string]any{"tested_on": "linux", "reviewers": []string(nil)},
+		},
+		{
+			name: "[正常系] 前の質問の回答を条件に使用する",
+			cd:   CommitData{Type: "feat: :sparkles:", Custom: map[string]any{"risk": "high", "rollback": true}},
+			want: map[string]any{"tested_on": "", "risk": "high", "rollback": true, "reviewers": []string(nil)},
+		},
+		{
+			name: "[正常系] 回答ファイルから読み込んだ値を揃える",
+			cd:   CommitData{Type: "fix: :bug:", Custom: map[string]any{"reviewers": []any{"alice"}}},
+			want: map[string]any{"tested_on": "", "reviewers": []string{"alice"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.cd.activeAnswers(createCustomQuestionsConfig())
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("activeAnswers() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCommitData_lintCustom(t *testing.T) {
+	tests := []struct {
+		name string
+		cd   CommitData
+		want []Violation
+	}{
+		{
+			name: "[正常系] すべての回答が正しい",
+			cd:   CommitData{Type: "feat: :sparkles:", Custom: map[string]any{"tested_on": "linux", "risk": "low", "reviewers": []string{"bob"}}},
+		},
+		{
+			name: "[異常系] 必須の回答がない",
+			cd:   CommitData{Type: "fix: :bug:"},
+			want: []Violation{{Stage: "tested_on", Message: "Answer is required"}},
+		},
+		{
+			name: "[異常系] パターンと選択肢に一致しない",
+			cd:   CommitData{Type: "feat: :sparkles:", Custom: map[string]any{"tested_on": "mac os", "risk": "medium", "reviewers": []string{"carol"}}},
+			want: []Violation{
+				{Stage: "tested_on", Message: `Answer must match ^\S+$`},
+				{Stage: "risk", Message: "Unknown choice: medium"},
+				{Stage: "reviewers", Message: "Unknown choice: carol"},
+			},
+		},
+		{
+			name: "[異常系] 設定に無い質問の回答",
+			cd:   CommitData{Type: "fix: :bug:", Custom: map[string]any{"tested_on": "linux", "owner": "me"}},
+			want: []Violation{{Stage: "owner", Message: "Unknown question: owner"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.cd.lintCustom(createCustomQuestionsConfig())
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("lintCustom() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
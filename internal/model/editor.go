@@ -0,0 +1,77 @@
+package model
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// editorTemplateComment is appended to the message written for the editor and removed afterwards
+const editorTemplateComment = `
+# Edit the commit message above. Lines starting with '#' will be ignored.
+# Save and close the editor to return to the confirmation.
+`
+
+// editorFinishedMsg is sent when the editor launched from the confirm stage exits
+type editorFinishedMsg struct {
+	path string
+	err  error
+}
+
+// openEditor はコミットメッセージを一時ファイルに書き出してエディタを起動する
+func (m Model) openEditor() (Model, tea.Cmd) {
+	f, err := os.CreateTemp("", "COMMIT_EDITMSG-*")
+	if err != nil {
+		m.editorErr = err
+		return m, nil
+	}
+	_, err = f.WriteString(m.GetCommitMessage() + "\n" + editorTemplateComment)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		m.editorErr = err
+		return m, nil
+	}
+
+	// gitと同様に引数を含むエディタの指定に対応するためシェル経由で起動する
+	editor := m.gitRepo.GetEditor()
+	cmd := exec.Command("sh", "-c", editor+` "$@"`, editor, f.Name())
+	return m, tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return editorFinishedMsg{path: f.Name(), err: err}
+	})
+}
+
+// handleEditorFinished は編集されたメッセージを読み込んで設定に対して検証する
+func (m Model) handleEditorFinished(msg editorFinishedMsg) Model {
+	defer os.Remove(msg.path)
+
+	m.editorErr = msg.err
+	if msg.err != nil {
+		return m
+	}
+	b, err := os.ReadFile(msg.path)
+	if err != nil {
+		m.editorErr = err
+		return m
+	}
+
+	message := StripComments(string(b))
+	if message == "" {
+		m.editorErr = errors.New("the edited message is empty, keeping the previous message")
+		return m
+	}
+
+	m.editedMessage = message
+	// カスタム質問の回答はメッセージから復元できないため引き継ぐ
+	custom := m.commitData.Custom
+	m.commitData = ParseCommitMessage(m.config, message)
+	m.commitData.Custom = custom
+	m.violations = m.commitData.Lint(m.config)
+	// 戻って回答し直す場合も編集後の値から始まるように各ステージの入力を合わせる
+	m.loadSteps(m.commitData)
+	return m
+}
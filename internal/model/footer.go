@@ -4,6 +4,7 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/cffnpwr/git-cz-go/pkg/theme"
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/textarea"
 	tea "github.com/charmbracelet/bubbletea"
@@ -14,18 +15,6 @@ const (
 	defaultFooterPrompt = "Enter footer ('word: content' or 'word #content')"
 )
 
-var (
-	footerSubmitKey = key.NewBinding(
-		key.WithKeys("alt+enter", "ctrl+enter"),
-		key.WithHelp("⌘+Enter/Ctrl+Enter", "submit footer"),
-	)
-
-	footerInfoColor  = lipgloss.Color("#696969")
-	footerErrorColor = lipgloss.Color("#ff0000")
-	footerInfoStyle  = lipgloss.NewStyle().Foreground(footerInfoColor)
-	footerErrorStyle = lipgloss.NewStyle().Foreground(footerErrorColor)
-)
-
 // footerStartPattern
 // 空白以外で構成された1つのトークンから始まり、`: `あるいは` #`が続くパターンで始まる
 var footerStartPattern = regexp.MustCompile(`^\S+((:\s)|(\s#))`)
@@ -37,6 +26,8 @@ type footerValidationResult struct {
 
 type FooterModel struct {
 	textarea textarea.Model
+	keys     KeyMap
+	styles   Styles
 	finished bool
 	valid    bool
 	errorMsg string
@@ -54,14 +45,50 @@ func NewFooterModel(prompt string) FooterModel {
 
 	return FooterModel{
 		textarea: ta,
+		keys:     DefaultKeyMap,
+		styles:   NewStyles(lipgloss.DefaultRenderer(), theme.Default),
 	}
 }
 
+// SetKeyMap sets the keys used to submit the footer
+func (m FooterModel) SetKeyMap(km KeyMap) FooterModel {
+	m.keys = km
+	return m
+}
+
+// SetStyles sets the styles of the messages under the textarea
+func (m FooterModel) SetStyles(s Styles) FooterModel {
+	m.styles = s
+	return m
+}
+
+// SetSize fits the textarea into the space left for the footer, keeping lines for the error and the hint
+func (m FooterModel) SetSize(width, height int) FooterModel {
+	m.textarea = fitTextarea(m.textarea, width, height, 2)
+	return m
+}
+
+// ShortHelp returns the keys shown in the help line, implementing help.KeyMap
+func (m FooterModel) ShortHelp() []key.Binding {
+	return []key.Binding{m.keys.Submit}
+}
+
+// FullHelp returns the keys shown in the full help, implementing help.KeyMap
+func (m FooterModel) FullHelp() [][]key.Binding {
+	return [][]key.Binding{m.ShortHelp()}
+}
+
 func (m FooterModel) GetPrompt() string {
 	return m.textarea.Placeholder
 }
 
-func (m FooterModel) Focus() {
+// Reset makes the model editable again while keeping the entered value
+func (m FooterModel) Reset() FooterModel {
+	m.finished = false
+	return m
+}
+
+func (m *FooterModel) Focus() {
 	m.textarea.Focus()
 }
 
@@ -76,7 +103,7 @@ func (m FooterModel) Update(msg tea.Msg) (FooterModel, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		if key.Matches(msg, footerSubmitKey) && (m.valid || strings.TrimSpace(m.textarea.Value()) == "") {
+		if key.Matches(msg, m.keys.Submit) && (m.valid || strings.TrimSpace(m.textarea.Value()) == "") {
 			m.finished = true
 			return m, nil
 		}
@@ -95,11 +122,11 @@ func (m FooterModel) View() string {
 	view := m.textarea.View()
 
 	if m.errorMsg != "" {
-		view += "\n" + footerErrorStyle.Render("✕ "+m.errorMsg)
+		view += "\n" + m.styles.Error.Render("✕ "+m.errorMsg)
 	}
 
 	if (m.valid || strings.TrimSpace(m.textarea.Value()) == "") && !m.finished {
-		view += "\n" + footerInfoStyle.Render("Press ⌘+Enter/Ctrl+Enter to continue")
+		view += "\n" + m.styles.Info.Render("Press ⌘+Enter/Ctrl+Enter to continue")
 	}
 
 	return view
@@ -114,7 +141,12 @@ func (m FooterModel) GetValue() string {
 }
 
 func (m FooterModel) validateInput() footerValidationResult {
-	value := strings.TrimSpace(m.textarea.Value())
+	return validateFooter(m.textarea.Value())
+}
+
+// validateFooter はフッターが`word: content`あるいは`word #content`形式であるかを検証する
+func validateFooter(value string) footerValidationResult {
+	value = strings.TrimSpace(value)
 
 	lines := strings.Split(value, "\n")
 	if len(lines) == 0 {
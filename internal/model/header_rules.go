@@ -0,0 +1,133 @@
+package model
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/cffnpwr/git-cz-go/config"
+	"github.com/mattn/go-runewidth"
+)
+
+// imperativeVerbs is the built-in list of verbs used to detect a non-imperative subject
+var imperativeVerbs = []string{
+	"add", "adjust", "allow", "apply", "avoid", "bump", "change", "check", "clean", "configure",
+	"convert", "correct", "create", "delete", "deprecate", "disable", "document", "drop", "enable", "ensure",
+	"export", "expose", "extract", "fix", "format", "handle", "hide", "implement", "import", "improve",
+	"include", "increase", "initialize", "install", "introduce", "load", "merge", "migrate", "move", "optimize",
+	"parse", "prevent", "provide", "reduce", "refactor", "release", "remove", "rename", "reorder", "replace",
+	"restore", "return", "revert", "rewrite", "run", "save", "set", "show", "simplify", "skip",
+	"sort", "split", "start", "stop", "support", "switch", "test", "tidy", "uninstall", "update",
+	"upgrade", "use", "validate", "wrap", "write",
+}
+
+// lintHeader はヘッダーと件名を設定されたルールで検証する
+func lintHeader(hCfg config.Header, cd CommitData) []Violation {
+	var violations []Violation
+	add := func(warning bool, format string, args ...any) {
+		violations = append(violations, Violation{
+			Stage:   StageSubject,
+			Message: fmt.Sprintf(format, args...),
+			Warning: warning,
+		})
+	}
+
+	subject := strings.TrimSpace(cd.Subject)
+	if subject == "" {
+		// 未設定の場合も件名は必須とする
+		if r := hCfg.Required; r == nil || r.Value {
+			add(r != nil && r.IsWarning(), "Subject is required")
+		}
+		return violations
+	}
+
+	// 本文の行幅やプレビューと同じく表示幅で数える
+	length := runewidth.StringWidth(cd.Header())
+	if r := hCfg.MinLength; r != nil && length < r.Value {
+		add(r.IsWarning(), "Header must be at least %d columns wide (currently %d)", r.Value, length)
+	}
+	if r := hCfg.MaxLength; r != nil && length > r.Value {
+		add(r.IsWarning(), "Header must be at most %d columns wide (currently %d)", r.Value, length)
+	}
+
+	if r := hCfg.SubjectCase; r != nil {
+		switch r.Value {
+		case config.CaseLower:
+			if subject != strings.ToLower(subject) {
+				add(r.IsWarning(), "Subject must be lower case")
+			}
+		case config.CaseSentence:
+			if first, _ := utf8.DecodeRuneInString(subject); unicode.IsLower(first) {
+				add(r.IsWarning(), "Subject must start with a capital letter")
+			}
+		}
+	}
+
+	if r := hCfg.NoTrailingPeriod; r != nil && r.Value {
+		if strings.HasSuffix(subject, ".") || strings.HasSuffix(subject, "。") {
+			add(r.IsWarning(), "Subject must not end with a period")
+		}
+	}
+
+	if r := hCfg.ForbiddenWords; r != nil {
+		for _, w := range r.Value {
+			if containsWord(subject, w) {
+				add(r.IsWarning(), "Subject must not contain %q", w)
+			}
+		}
+	}
+
+	if r := hCfg.Imperative; r != nil && r.Value {
+		if words := strings.FieldsFunc(subject, isWordSeparator); len(words) > 0 {
+			first := strings.ToLower(words[0])
+			if base, ok := imperativeBase(first); ok {
+				add(r.IsWarning(), "Subject should use the imperative mood (%q instead of %q)", base, first)
+			}
+		}
+	}
+
+	return violations
+}
+
+func isWordSeparator(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsNumber(r) && r != '-' && r != '\''
+}
+
+// containsWord は大文字小文字を区別せずに単語が含まれているかを判定する
+// 空白で区切られない日本語などの単語は部分一致で判定する
+func containsWord(s, word string) bool {
+	s, word = strings.ToLower(s), strings.ToLower(word)
+	if strings.ContainsFunc(word, func(r rune) bool { return r > unicode.MaxASCII || isWordSeparator(r) }) {
+		return strings.Contains(s, word)
+	}
+	return slices.Contains(strings.FieldsFunc(s, isWordSeparator), word)
+}
+
+// imperativeBase は命令形でない動詞の原形を返す
+// 組み込みの動詞リストに無い単語は判定しない
+func imperativeBase(word string) (string, bool) {
+	if slices.Contains(imperativeVerbs, word) {
+		return "", false
+	}
+
+	var candidates []string
+	for _, suffix := range []string{"ing", "ed", "es", "s", "d"} {
+		stem, ok := strings.CutSuffix(word, suffix)
+		if !ok || stem == "" {
+			continue
+		}
+		candidates = append(candidates, stem, stem+"e")
+		// dropped, running のように子音字を重ねた形
+		if n := len(stem); n > 1 && stem[n-1] == stem[n-2] {
+			candidates = append(candidates, stem[:n-1])
+		}
+	}
+	for _, c := range candidates {
+		if slices.Contains(imperativeVerbs, c) {
+			return c, true
+		}
+	}
+	return "", false
+}
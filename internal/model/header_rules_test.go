@@ -0,0 +1,125 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/cffnpwr/git-cz-go/config"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLintHeader(t *testing.T) {
+	hCfg := config.Header{
+		MaxLength:        &config.Rule[int]{Value: 30},
+		MinLength:        &config.Rule[int]{Value: 15, Level: config.RuleLevelWarning},
+		SubjectCase:      &config.Rule[config.Case]{Value: config.CaseLower},
+		NoTrailingPeriod: &config.Rule[bool]{Value: true},
+		ForbiddenWords:   &config.Rule[[]string]{Value: []string{"wip", "仮"}},
+		Imperative:       &config.Rule[bool]{Value: true, Level: config.RuleLevelWarning},
+	}
+
+	tests := []struct {
+		name    string
+		hCfg    config.Header
+		subject string
+		want    []Violation
+	}{
+		{
+			name:    "[正常系] 全てのルールを満たす",
+			hCfg:    hCfg,
+			subject: "add login form",
+		},
+		{
+			name:    "[正常系] ルール未設定でも件名は必須",
+			subject: "",
+			want:    []Violation{{Stage: StageSubject, Message: "Subject is required"}},
+		},
+		{
+			name: "[正常系] 件名の必須を無効化",
+			hCfg: config.Header{Required: &config.Rule[bool]{Value: false}},
+		},
+		{
+			name:    "[異常系] 長さの下限を下回る",
+			hCfg:    hCfg,
+			subject: "fix",
+			want: []Violation{
+				{Stage: StageSubject, Message: "Header must be at least 15 columns wide (currently 9)", Warning: true},
+			},
+		},
+		{
+			name:    "[異常系] 長さの上限を超える",
+			hCfg:    hCfg,
+			subject: "add a very long subject line here",
+			want: []Violation{
+				{Stage: StageSubject, Message: "Header must be at most 30 columns wide (currently 39)"},
+			},
+		},
+		{
+			name:    "[異常系] 大文字、末尾のピリオド、禁止語、命令形",
+			hCfg:    hCfg,
+			subject: "Added WIP form.",
+			want: []Violation{
+				{Stage: StageSubject, Message: "Subject must be lower case"},
+				{Stage: StageSubject, Message: "Subject must not end with a period"},
+				{Stage: StageSubject, Message: `Subject must not contain "wip"`},
+				{Stage: StageSubject, Message: `Subject should use the imperative mood ("add" instead of "added")`, Warning: true},
+			},
+		},
+		{
+			name:    "[異常系] 日本語の禁止語と句点",
+			hCfg:    hCfg,
+			subject: "仮の実装を追加。",
+			want: []Violation{
+				{Stage: StageSubject, Message: "Subject must not end with a period"},
+				{Stage: StageSubject, Message: `Subject must not contain "仮"`},
+			},
+		},
+		{
+			name:    "[異常系] 全角の文字は2桁として数える",
+			hCfg:    hCfg,
+			subject: "ログインフォームの検証を追加",
+			want: []Violation{
+				{Stage: StageSubject, Message: "Header must be at most 30 columns wide (currently 34)"},
+			},
+		},
+		{
+			name:    "[異常系] 文頭が小文字",
+			hCfg:    config.Header{SubjectCase: &config.Rule[config.Case]{Value: config.CaseSentence}},
+			subject: "add form",
+			want:    []Violation{{Stage: StageSubject, Message: "Subject must start with a capital letter"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := lintHeader(tt.hCfg, CommitData{Type: "feat", Subject: tt.subject})
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("lintHeader() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestImperativeBase(t *testing.T) {
+	tests := []struct {
+		word   string
+		want   string
+		wantOk bool
+	}{
+		{word: "add"},
+		{word: "adds", want: "add", wantOk: true},
+		{word: "updated", want: "update", wantOk: true},
+		{word: "fixes", want: "fix", wantOk: true},
+		{word: "dropped", want: "drop", wantOk: true},
+		{word: "removing", want: "remove", wantOk: true},
+		{word: "readme"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			got, ok := imperativeBase(tt.word)
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("imperativeBase(%q) = %q, %v, want %q, %v", tt.word, got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
@@ -0,0 +1,306 @@
+package model
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/cffnpwr/git-cz-go/config"
+	"github.com/cffnpwr/git-cz-go/pkg/component/choice"
+	"github.com/cffnpwr/git-cz-go/pkg/component/confirm"
+	"github.com/cffnpwr/git-cz-go/pkg/component/selector"
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// KeyMap holds the keys of the wizard and of the components it uses
+type KeyMap struct {
+	Quit   key.Binding
+	Back   key.Binding
+	Enter  key.Binding // Finishes the single-line inputs and the multi-select
+	Submit key.Binding // Finishes the multi-line inputs
+	Bullet key.Binding
+	Up     key.Binding // Used by the multi-select
+	Down   key.Binding // Used by the multi-select
+	Toggle key.Binding // Used by the multi-select
+	Yes    key.Binding
+	No     key.Binding
+	Edit   key.Binding
+	Help   key.Binding
+
+	Selector selector.KeyMap
+	Confirm  confirm.KeyMap
+	Choice   choice.KeyMap
+}
+
+// quitKey は終了のキー。コンポーネントの終了のキーも揃え、前の質問へ戻るEscでは終了しない
+var quitKey = key.NewBinding(
+	key.WithKeys("ctrl+c"),
+	key.WithHelp("Ctrl+C", "quit"),
+)
+
+// DefaultKeyMap は設定でキーを指定しなかった場合のキーバインド
+// 前の質問へ戻るキーはモデルが先に処理するため、選択肢の移動には含めない
+var DefaultKeyMap = KeyMap{
+	Quit: quitKey,
+	Back: key.NewBinding(
+		key.WithKeys("shift+tab", "esc"),
+		key.WithHelp("Shift+Tab/Esc", "previous question"),
+	),
+	Enter: key.NewBinding(
+		key.WithKeys("enter"),
+		key.WithHelp("Enter", "confirm"),
+	),
+	Submit: key.NewBinding(
+		key.WithKeys("alt+enter", "ctrl+enter"),
+		key.WithHelp("⌘+Enter/Ctrl+Enter", "submit changes"),
+	),
+	Bullet: key.NewBinding(
+		key.WithKeys("ctrl+o"),
+		key.WithHelp("Ctrl+O", "new bullet"),
+	),
+	Up: key.NewBinding(
+		key.WithKeys("up", "ctrl+p"),
+		key.WithHelp("↑/Ctrl+P", "move up"),
+	),
+	Down: key.NewBinding(
+		key.WithKeys("down", "ctrl+n"),
+		key.WithHelp("↓/Ctrl+N", "move down"),
+	),
+	Toggle: key.NewBinding(
+		key.WithKeys("tab"),
+		key.WithHelp("Tab", "toggle item"),
+	),
+	Yes: key.NewBinding(
+		key.WithKeys("y"),
+		key.WithHelp("y", "commit"),
+	),
+	No: key.NewBinding(
+		key.WithKeys("n"),
+		key.WithHelp("n", "cancel"),
+	),
+	Edit: key.NewBinding(
+		key.WithKeys("e"),
+		key.WithHelp("e", "edit in editor"),
+	),
+	Help: key.NewBinding(
+		key.WithKeys("?", "f1"),
+		key.WithHelp("?/F1", "toggle help"),
+	),
+
+	Selector: func() selector.KeyMap {
+		km := selector.DefaultKeyMap
+		km.Quit = quitKey
+		return km
+	}(),
+	Confirm: confirm.KeyMap{
+		Toggle: key.NewBinding(
+			key.WithKeys("tab", "l", "right", "h", "left"),
+			key.WithHelp("←/→", "toggle selection"),
+		),
+		Affirmative: confirm.DefaultKeyMap.Affirmative,
+		Negative:    confirm.DefaultKeyMap.Negative,
+		Select:      confirm.DefaultKeyMap.Select,
+		Quit:        quitKey,
+	},
+	Choice: choice.KeyMap{
+		Next: choice.DefaultKeyMap.Next,
+		Prev: key.NewBinding(
+			key.WithKeys("h", "left", "k", "up"),
+			key.WithHelp("←/h", "previous option"),
+		),
+		Select: choice.DefaultKeyMap.Select,
+		Quit:   quitKey,
+	},
+}
+
+// typingActions は文字を入力する画面でも有効な操作。1文字のキーを割り当てると入力できなくなる
+var typingActions = []string{"quit", "back", "enter", "submit", "bullet"}
+
+// NewKeyMap applies the configured keys over DefaultKeyMap and reports the keys bound to two actions used together
+func NewKeyMap(keys config.Keys) (KeyMap, error) {
+	km := DefaultKeyMap
+	for _, a := range keys.Actions() {
+		if a.Keys == nil {
+			continue
+		}
+		if slices.Contains(typingActions, a.Name) {
+			for _, k := range a.Keys {
+				if k := keyName(k); utf8.RuneCountInString(k) == 1 {
+					return KeyMap{}, fmt.Errorf("invalid key for %s: %q is typed into the inputs", a.Name, k)
+				}
+			}
+		}
+
+		// 1つの操作を複数のコンポーネントで使うため、それぞれの説明のまま差し替える
+		var targets []*key.Binding
+		switch a.Name {
+		case "quit":
+			targets = []*key.Binding{&km.Quit, &km.Selector.Quit, &km.Confirm.Quit, &km.Choice.Quit}
+		case "back":
+			targets = []*key.Binding{&km.Back}
+		case "enter":
+			targets = []*key.Binding{&km.Enter}
+		case "submit":
+			targets = []*key.Binding{&km.Submit}
+		case "bullet":
+			targets = []*key.Binding{&km.Bullet}
+		case "up":
+			targets = []*key.Binding{&km.Up, &km.Selector.Up}
+		case "down":
+			targets = []*key.Binding{&km.Down, &km.Selector.Down}
+		case "toggle":
+			targets = []*key.Binding{&km.Toggle, &km.Selector.Toggle}
+		case "select":
+			targets = []*key.Binding{&km.Selector.Select, &km.Confirm.Select, &km.Choice.Select}
+		case "prev":
+			targets = []*key.Binding{&km.Choice.Prev}
+		case "next":
+			targets = []*key.Binding{&km.Choice.Next}
+		case "yes":
+			targets = []*key.Binding{&km.Yes, &km.Confirm.Affirmative}
+		case "no":
+			targets = []*key.Binding{&km.No, &km.Confirm.Negative}
+		case "edit":
+			targets = []*key.Binding{&km.Edit}
+		case "help":
+			targets = []*key.Binding{&km.Help}
+		}
+		for _, b := range targets {
+			*b = rebind(*b, a.Keys)
+		}
+	}
+	if keys.Prev != nil || keys.Next != nil {
+		km.Confirm.Toggle = rebind(km.Confirm.Toggle, slices.Concat(km.Choice.Prev.Keys(), km.Choice.Next.Keys()))
+	}
+
+	if err := km.validate(); err != nil {
+		return KeyMap{}, err
+	}
+	return km, nil
+}
+
+type namedBinding struct {
+	name    string
+	binding key.Binding
+}
+
+// validate は同時に有効になる操作の間でキーが重なっていないかを検証する
+func (km KeyMap) validate() error {
+	quit := namedBinding{"quit", km.Quit}
+	back := namedBinding{"back", km.Back}
+	help := namedBinding{"help", km.Help}
+	multi := km.multiSelector()
+	contexts := [][]namedBinding{
+		// 1行の入力
+		{quit, back, help, {"enter", km.Enter}},
+		// 複数行の入力。Enterは改行に使う
+		{quit, back, help, {"submit", km.Submit}, {"bullet", km.Bullet}, {"newline", key.NewBinding(key.WithKeys("enter"))}},
+		// 単一選択
+		{quit, back, help, {"quit", km.Selector.Quit}, {"up", km.Selector.Up}, {"down", km.Selector.Down}, {"select", km.Selector.Select}},
+		// 複数選択
+		{quit, back, help, {"quit", km.Selector.Quit}, {"up", km.Up}, {"down", km.Down}, {"toggle", km.Toggle}, {"enter", km.Enter},
+			{"select_all", multi.SelectAll}, {"invert", multi.Invert}},
+		// Yes/Noの確認
+		{quit, back, help, {"quit", km.Confirm.Quit}, {"toggle", km.Confirm.Toggle}, {"yes", km.Confirm.Affirmative},
+			{"no", km.Confirm.Negative}, {"select", km.Confirm.Select}},
+		// 確認
+		{quit, back, help, {"quit", km.Choice.Quit}, {"prev", km.Choice.Prev}, {"next", km.Choice.Next}, {"select", km.Choice.Select},
+			{"yes", km.Yes}, {"no", km.No}, {"edit", km.Edit}},
+	}
+
+	for _, c := range contexts {
+		owner := map[string]string{}
+		for _, nb := range c {
+			for _, k := range nb.binding.Keys() {
+				if other, ok := owner[k]; ok && other != nb.name {
+					return fmt.Errorf("conflicting keys: %q is bound to both %s and %s", k, other, nb.name)
+				}
+				owner[k] = nb.name
+			}
+		}
+	}
+	return nil
+}
+
+// multiSelector returns the keys of the selector choosing several items while typing into its filter
+func (km KeyMap) multiSelector() selector.KeyMap {
+	s := km.Selector
+	s.Up, s.Down, s.Toggle, s.Select = km.Up, km.Down, km.Toggle, km.Enter
+	// 1文字のキーは絞り込みの入力になるため除く
+	s.SelectAll = withoutRunes(s.SelectAll)
+	s.Invert = withoutRunes(s.Invert)
+	return s
+}
+
+// multiSelectShortHelp returns the keys of the multi-select shown in the help line
+func (km KeyMap) multiSelectShortHelp(filtering bool) []key.Binding {
+	return km.withClearFilter([]key.Binding{km.Up, km.Down, km.Toggle, km.Enter}, filtering)
+}
+
+// withClearFilter は絞り込み中のみ絞り込みを解除するキーを加える
+func (km KeyMap) withClearFilter(bindings []key.Binding, filtering bool) []key.Binding {
+	if filtering {
+		return append(bindings, km.Selector.ClearFilter)
+	}
+	return bindings
+}
+
+// multiSelectFullHelp returns the keys of the multi-select shown in the full help
+func (km KeyMap) multiSelectFullHelp() [][]key.Binding {
+	s := km.multiSelector()
+	return [][]key.Binding{{km.Up, km.Down}, {km.Toggle, s.SelectAll, s.Invert}, {km.Enter}}
+}
+
+// withoutRunes は1文字のキーを除いたキーバインドを返す
+func withoutRunes(b key.Binding) key.Binding {
+	var keys []string
+	for _, k := range b.Keys() {
+		if utf8.RuneCountInString(k) > 1 {
+			keys = append(keys, k)
+		}
+	}
+	return rebind(b, keys)
+}
+
+// rebind は説明を残したまま設定されたキーに差し替えたキーバインドを返す
+func rebind(b key.Binding, keys []string) key.Binding {
+	names := make([]string, len(keys))
+	for i, k := range keys {
+		names[i] = keyName(k)
+	}
+	b.SetKeys(names...)
+	b.SetHelp(keyHelp(names), b.Help().Desc)
+	return b
+}
+
+// keyName は設定での表記を Bubble Tea のキー名に変換する
+func keyName(k string) string {
+	if k == "space" {
+		return " "
+	}
+	return k
+}
+
+// keyHelp はキー名をヘルプ表示用に "Ctrl+D/Esc" のような形式にする
+func keyHelp(names []string) string {
+	helps := make([]string, len(names))
+	for i, name := range names {
+		if name == " " {
+			helps[i] = "Space"
+			continue
+		}
+		parts := strings.Split(name, "+")
+		for j, p := range parts {
+			// 単独の文字は大文字と区別するためそのまま表示する
+			if p == "" || len(parts) == 1 && utf8.RuneCountInString(p) == 1 {
+				continue
+			}
+			r, size := utf8.DecodeRuneInString(p)
+			parts[j] = string(unicode.ToUpper(r)) + p[size:]
+		}
+		helps[i] = strings.Join(parts, "+")
+	}
+	return strings.Join(helps, "/")
+}
@@ -0,0 +1,277 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/cffnpwr/git-cz-go/config"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNewKeyMap(t *testing.T) {
+	tests := []struct {
+		name       string
+		keys       config.Keys
+		msg        tea.KeyMsg
+		binding    func(KeyMap) key.Binding
+		wantHelp   string
+		wantErrMsg string
+	}{
+		{
+			name:     "[正常系] 設定がなければ既定のキー",
+			msg:      tea.KeyMsg{Type: tea.KeyEnter, Alt: true},
+			binding:  func(km KeyMap) key.Binding { return km.Submit },
+			wantHelp: "⌘+Enter/Ctrl+Enter",
+		},
+		{
+			name:     "[正常系] 複数行の入力を完了するキーを変更",
+			keys:     config.Keys{Submit: []string{"ctrl+d", "alt+enter"}},
+			msg:      tea.KeyMsg{Type: tea.KeyCtrlD},
+			binding:  func(km KeyMap) key.Binding { return km.Submit },
+			wantHelp: "Ctrl+D/Alt+Enter",
+		},
+		{
+			name:     "[正常系] 選択のキーをコンポーネントにも反映",
+			keys:     config.Keys{Select: []string{"space"}},
+			msg:      tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}},
+			binding:  func(km KeyMap) key.Binding { return km.Choice.Select },
+			wantHelp: "Space",
+		},
+		{
+			name:     "[正常系] 選択肢の移動のキーを確認の切り替えにも反映",
+			keys:     config.Keys{Next: []string{"right"}, Prev: []string{"left"}},
+			msg:      tea.KeyMsg{Type: tea.KeyLeft},
+			binding:  func(km KeyMap) key.Binding { return km.Confirm.Toggle },
+			wantHelp: "Left/Right",
+		},
+		{
+			// Escは前の質問へ戻るキーのため、コンポーネントでも終了に使わない
+			name:     "[正常系] コンポーネントの終了は全体の終了と同じキー",
+			msg:      tea.KeyMsg{Type: tea.KeyCtrlC},
+			binding:  func(km KeyMap) key.Binding { return km.Choice.Quit },
+			wantHelp: "Ctrl+C",
+		},
+		{
+			name:       "[異常系] 確認画面で同じキーを2つの操作に割り当て",
+			keys:       config.Keys{Edit: []string{"y"}},
+			wantErrMsg: `conflicting keys: "y" is bound to both yes and edit`,
+		},
+		{
+			name:       "[異常系] 改行のEnterで複数行の入力を完了",
+			keys:       config.Keys{Submit: []string{"enter"}},
+			wantErrMsg: `conflicting keys: "enter" is bound to both submit and newline`,
+		},
+		{
+			name:       "[異常系] 前の質問へ戻るキーと複数選択の切り替えが重複",
+			keys:       config.Keys{Back: []string{"tab"}},
+			wantErrMsg: `conflicting keys: "tab" is bound to both back and toggle`,
+		},
+		{
+			name:       "[異常系] 入力欄に入力される文字で終了",
+			keys:       config.Keys{Quit: []string{"q"}},
+			wantErrMsg: `invalid key for quit: "q" is typed into the inputs`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			km, err := NewKeyMap(tt.keys)
+			if tt.wantErrMsg != "" {
+				if err == nil || err.Error() != tt.wantErrMsg {
+					t.Fatalf("NewKeyMap() error = %v, want %q", err, tt.wantErrMsg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewKeyMap() error = %v", err)
+			}
+
+			b := tt.binding(km)
+			if !key.Matches(tt.msg, b) {
+				t.Errorf("key %q does not match %v", tt.msg.String(), b.Keys())
+			}
+			if got := b.Help().Key; got != tt.wantHelp {
+				t.Errorf("Help().Key = %q, want %q", got, tt.wantHelp)
+			}
+		})
+	}
+}
+
+func TestModel_Keys(t *testing.T) {
+	cfg := createTestConfig()
+	cfg.Keys = config.Keys{Back: []string{"ctrl+b"}}
+	m := createTestPreviewModel(t, cfg)
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if got := m.currentStage(); got != StageScope {
+		t.Fatalf("currentStage() = %q, want %q", got, StageScope)
+	}
+
+	// 既定のEscでは戻らない
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(Model)
+	if got := m.currentStage(); got != StageScope {
+		t.Errorf("currentStage() after Esc = %q, want %q", got, StageScope)
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlB})
+	m = updated.(Model)
+	if got := m.currentStage(); got != StageTypeSelect {
+		t.Errorf("currentStage() after Ctrl+B = %q, want %q", got, StageTypeSelect)
+	}
+}
+
+func TestModel_EscClearsFilter(t *testing.T) {
+	esc := tea.KeyMsg{Type: tea.KeyEsc}
+
+	tests := []struct {
+		name   string
+		stage  Stage
+		filter func(Model) string
+	}{
+		{
+			name:   "[正常系] タイプの絞り込みを解除",
+			stage:  StageTypeSelect,
+			filter: func(m Model) string { return m.wizard.Step(string(StageTypeSelect)).(typeStep).model.GetFilter() },
+		},
+		{
+			name:   "[正常系] 複数選択のカスタム質問の絞り込みを解除",
+			stage:  "areas",
+			filter: func(m Model) string { return m.wizard.Step("areas").(questionStep).model.choices.GetFilter() },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createTestConfig()
+			cfg.Questions = []config.Question{{ID: "areas", Kind: config.QuestionKindMultiselect, Choices: []string{"api", "ui"}}}
+			m := createTestPreviewModel(t, cfg)
+			m.enterStage(tt.stage)
+
+			updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("u")})
+			m = updated.(Model)
+			if got := tt.filter(m); got != "u" {
+				t.Fatalf("filter = %q, want %q", got, "u")
+			}
+
+			// 絞り込み中のEscは戻らずに絞り込みを解除する
+			updated, _ = m.Update(esc)
+			m = updated.(Model)
+			if got := m.currentStage(); got != tt.stage {
+				t.Errorf("currentStage() = %q, want %q", got, tt.stage)
+			}
+			if got := tt.filter(m); got != "" {
+				t.Errorf("filter = %q, want empty", got)
+			}
+
+			// 絞り込みが無ければ前の質問へ戻る
+			want := tt.stage
+			if prev, ok := m.wizard.Prev(m.skipFunc()); ok {
+				want = Stage(prev)
+			}
+			updated, _ = m.Update(esc)
+			m = updated.(Model)
+			if got := m.currentStage(); got != want {
+				t.Errorf("currentStage() after the second Esc = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+// helpDescs はヘルプに表示される操作の説明を返す
+func helpDescs(bindings []key.Binding) []string {
+	var descs []string
+	for _, b := range bindings {
+		descs = append(descs, b.Help().Desc)
+	}
+	return descs
+}
+
+func TestModel_Help(t *testing.T) {
+	question := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("?")}
+	subjectModel := func(t *testing.T) Model {
+		m := createTestPreviewModel(t, createTestConfig())
+		m.enterStage(StageSubject)
+		return m
+	}
+
+	tests := []struct {
+		name        string
+		model       func(t *testing.T) Model
+		keyInputs   []tea.KeyMsg
+		wantShort   []string
+		wantShowAll bool
+	}{
+		{
+			name:        "[正常系] 最初のステージでは戻るキーを表示しない",
+			model:       func(t *testing.T) Model { return createTestPreviewModel(t, createTestConfig()) },
+			keyInputs:   []tea.KeyMsg{question},
+			wantShort:   []string{"move up", "move down", "select item", "toggle help", "quit"},
+			wantShowAll: true,
+		},
+		{
+			name:      "[正常系] タイプの絞り込み中は?を入力として扱う",
+			model:     func(t *testing.T) Model { return createTestPreviewModel(t, createTestConfig()) },
+			keyInputs: []tea.KeyMsg{{Type: tea.KeyRunes, Runes: []rune("f")}, question},
+			// 絞り込みの入力として扱う
+			wantShowAll: false,
+		},
+		{
+			name:        "[正常系] 件名の入力中は?を入力として扱う",
+			model:       subjectModel,
+			keyInputs:   []tea.KeyMsg{question},
+			wantShowAll: false,
+		},
+		{
+			name:        "[正常系] 件名の入力中もF1で全体のヘルプを表示",
+			model:       subjectModel,
+			keyInputs:   []tea.KeyMsg{{Type: tea.KeyF1}},
+			wantShowAll: true,
+		},
+		{
+			name:        "[正常系] 確認画面で全体のヘルプを表示",
+			model:       func(t *testing.T) Model { return createTestConfirmModel(t, createTestConfig()) },
+			keyInputs:   []tea.KeyMsg{question},
+			wantShort:   []string{"commit", "edit in editor", "cancel", "confirm selection", "previous question", "toggle help", "quit"},
+			wantShowAll: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := tt.model(t)
+			if tt.wantShort != nil {
+				if diff := cmp.Diff(tt.wantShort, helpDescs(m.ShortHelp())); diff != "" {
+					t.Errorf("ShortHelp() mismatch (-want +got):\n%s", diff)
+				}
+			}
+
+			for _, msg := range tt.keyInputs {
+				updated, _ := m.Update(msg)
+				m = updated.(Model)
+			}
+			if m.help.ShowAll != tt.wantShowAll {
+				t.Errorf("ShowAll = %v, want %v", m.help.ShowAll, tt.wantShowAll)
+			}
+		})
+	}
+}
+
+func TestBodyModel_Help(t *testing.T) {
+	km, err := NewKeyMap(config.Keys{Submit: []string{"ctrl+d"}})
+	if err != nil {
+		t.Fatalf("NewKeyMap() error = %v", err)
+	}
+	m := NewBodyModel("", config.Body{}).SetKeyMap(km)
+
+	// 変更したキーを元の説明で表示する
+	var got []string
+	for _, b := range m.ShortHelp() {
+		got = append(got, b.Help().Key+" "+b.Help().Desc)
+	}
+	if diff := cmp.Diff([]string{"Ctrl+D submit changes", "Ctrl+O new bullet"}, got); diff != "" {
+		t.Errorf("ShortHelp() mismatch (-want +got):\n%s", diff)
+	}
+}
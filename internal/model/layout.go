@@ -0,0 +1,70 @@
+package model
+
+import (
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// defaultTextareaHeight is the height of the multi-line inputs when the terminal has room for it
+const defaultTextareaHeight = 6
+
+// resize は各ステージに端末の幅と、質問以外の表示を除いた高さを渡す。高さが分からない場合は0を渡す
+func (m *Model) resize() {
+	if m.width == 0 {
+		return
+	}
+	// 質問の1行目はアイコンの後に続く
+	icon := lipgloss.Width(m.styles.Icon.Render(defaultIconCharQuestion))
+	_, total := m.viewHeights()
+	others := total - lipgloss.Height(m.getStageView(m.currentStage()))
+	width, height := max(1, m.width-icon), 0
+	if m.height > 0 {
+		height = max(1, m.height-others)
+	}
+	for _, id := range m.wizard.IDs() {
+		if step, ok := m.wizard.Step(id).(stageStep); ok {
+			m.wizard = m.wizard.SetStep(id, step.resize(width, height))
+		}
+	}
+}
+
+// viewHeights は画面全体を組み立てずに各部分の高さから、現在のステージより上の行数と、
+// 端末の高さまで埋める前の画面全体の行数を求める
+func (m Model) viewHeights() (above, total int) {
+	progress, current := m.buildProgressView(), m.buildCurrentView()
+	above = lipgloss.Height(progress)
+	main := above + lipgloss.Height(current)
+	if m.showPreview() {
+		panel, beside := m.buildPreviewPanel(max(lipgloss.Width(progress), lipgloss.Width(current)))
+		if beside {
+			main = max(main, lipgloss.Height(panel))
+		} else {
+			main += lipgloss.Height(panel)
+		}
+	}
+	// ヘルプの前の空行と末尾の改行の分を加える
+	return above, main + 1 + lipgloss.Height(m.help.View(m)) + 1
+}
+
+// wrap は端末の幅を超えるヒントなどの行を折り返す
+func (m Model) wrap(s string) string {
+	if m.width <= 0 {
+		return s
+	}
+	return ansi.Wrap(s, m.width, "")
+}
+
+// fitInput はプロンプトとカーソルの分を除いた幅に入力欄を合わせる
+func fitInput(ti textinput.Model, width int) textinput.Model {
+	ti.Width = max(1, width-lipgloss.Width(ti.Prompt)-1)
+	return ti
+}
+
+// fitTextarea は幅と、下に表示するヒントなどの行を除いた高さに入力欄を合わせる
+func fitTextarea(ta textarea.Model, width, height, reserved int) textarea.Model {
+	ta.SetWidth(width)
+	ta.SetHeight(max(1, min(defaultTextareaHeight, height-reserved)))
+	return ta
+}
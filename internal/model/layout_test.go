@@ -0,0 +1,178 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cffnpwr/git-cz-go/config"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestModel_Resize(t *testing.T) {
+	longTypesConfig := func() *config.Config {
+		cfg := createTestConfig()
+		cfg.Types = []config.TypeValue{
+			{Value: "feat: ✨", Name: "✨ feat: ユーザーに見える新しい機能を追加する", Description: "新しい機能"},
+			{Value: "fix: 🐛", Name: "🐛 fix: 不具合を修正して正しく動くようにする"},
+			{Value: "docs: 📝", Name: "📝 docs: ドキュメントだけを変更する"},
+			{Value: "style: 💄", Name: "💄 style: 動作に影響しない見た目の変更"},
+			{Value: "refactor: ♻️", Name: "♻️ refactor: 機能を変えずにコードを整理する"},
+		}
+		return cfg
+	}
+
+	tests := []struct {
+		name          string
+		model         func(t *testing.T) Model
+		stage         Stage
+		width, height int
+		wantText      string
+	}{
+		{
+			name:     "[正常系] 長いタイプ名を幅で切り詰める",
+			model:    func(t *testing.T) Model { return createTestPreviewModel(t, longTypesConfig()) },
+			stage:    StageTypeSelect,
+			width:    40,
+			height:   20,
+			wantText: "…",
+		},
+		{
+			name:   "[正常系] 本文の入力欄を幅と高さに合わせる",
+			model:  func(t *testing.T) Model { return createTestPreviewModel(t, longTypesConfig()) },
+			stage:  StageBody,
+			width:  40,
+			height: 16,
+		},
+		{
+			name:     "[正常系] 確認画面のメッセージを枠の中で折り返す",
+			model:    func(t *testing.T) Model { return createTestConfirmModel(t, createTestConfig()) },
+			stage:    StageConfirm,
+			width:    30,
+			height:   60,
+			wantText: "Yes",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := tt.model(t)
+			m.enterStage(tt.stage)
+			updated, _ := m.Update(tea.WindowSizeMsg{Width: tt.width, Height: tt.height})
+			m = updated.(Model)
+
+			view := m.View()
+			for _, line := range strings.Split(view, "\n") {
+				if w := lipgloss.Width(line); w > tt.width {
+					t.Errorf("line %q has width %d, want at most %d", line, w, tt.width)
+				}
+			}
+			// 末尾の改行の後の空行を除く
+			if got := lipgloss.Height(strings.TrimSuffix(view, "\n")); got > tt.height {
+				t.Errorf("View() has %d lines, want at most %d\n%s", got, tt.height, view)
+			}
+			if !strings.Contains(view, tt.wantText) {
+				t.Errorf("View() does not contain %q\n%s", tt.wantText, view)
+			}
+		})
+	}
+}
+
+func TestModel_ViewHeights(t *testing.T) {
+	withPreview := func(position string) *config.Config {
+		cfg := createTestConfig()
+		cfg.Preview.Position = position
+		return cfg
+	}
+
+	tests := []struct {
+		name  string
+		model func(t *testing.T) Model
+		width int
+	}{
+		{
+			name:  "[正常系] プレビューを下に表示",
+			model: func(t *testing.T) Model { return createTestPreviewModel(t, withPreview(config.PreviewPositionBottom)) },
+			width: 100,
+		},
+		{
+			name:  "[正常系] プレビューを右に表示",
+			model: func(t *testing.T) Model { return createTestPreviewModel(t, withPreview(config.PreviewPositionRight)) },
+			width: 120,
+		},
+		{
+			name:  "[正常系] 幅が狭く右のプレビューを下に表示",
+			model: func(t *testing.T) Model { return createTestPreviewModel(t, withPreview(config.PreviewPositionRight)) },
+			width: 40,
+		},
+		{
+			name:  "[正常系] プレビューを表示しない",
+			model: func(t *testing.T) Model { return createTestPreviewModel(t, withPreview(config.PreviewPositionNone)) },
+			width: 100,
+		},
+		{
+			name:  "[正常系] 確認画面",
+			model: func(t *testing.T) Model { return createTestConfirmModel(t, createTestConfig()) },
+			width: 60,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := tt.model(t)
+			updated, _ := m.Update(tea.WindowSizeMsg{Width: tt.width, Height: 100})
+			m = updated.(Model)
+
+			above, total := m.viewHeights()
+			if want := lipgloss.Height(m.buildProgressView()); above != want {
+				t.Errorf("above = %d, want %d", above, want)
+			}
+			if want := lipgloss.Height(m.View()); total != want {
+				t.Errorf("total = %d, want %d\n%s", total, want, m.View())
+			}
+		})
+	}
+}
+
+func TestModel_View_FillHeight(t *testing.T) {
+	tests := []struct {
+		name      string
+		mouse     bool
+		altScreen bool
+		want      int
+	}{
+		{
+			name:  "[正常系] マウスを使う場合は端末の高さまで埋める",
+			mouse: true,
+			want:  40,
+		},
+		{
+			name:      "[正常系] 代替画面では埋めない",
+			mouse:     true,
+			altScreen: true,
+		},
+		{
+			name: "[正常系] マウスを使わない場合は埋めない",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createTestConfig()
+			cfg.Mouse = tt.mouse
+			cfg.AltScreen = tt.altScreen
+			m := createTestPreviewModel(t, cfg)
+			updated, _ := m.Update(tea.WindowSizeMsg{Width: 100, Height: 40})
+			m = updated.(Model)
+
+			got := strings.Count(m.View(), "\n") + 1
+			if tt.want == 0 {
+				// 埋めない場合は各部分の高さの合計になる
+				_, tt.want = m.viewHeights()
+			}
+			if got != tt.want {
+				t.Errorf("View() has %d lines, want %d", got, tt.want)
+			}
+		})
+	}
+}
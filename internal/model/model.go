@@ -3,49 +3,39 @@ package model
 import (
 	"fmt"
 	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/cffnpwr/git-cz-go/config"
 	"github.com/cffnpwr/git-cz-go/internal/interface/repo"
-	"github.com/cffnpwr/git-cz-go/pkg/component/confirm"
+	"github.com/cffnpwr/git-cz-go/pkg/component/choice"
 	"github.com/cffnpwr/git-cz-go/pkg/component/selector"
+	"github.com/cffnpwr/git-cz-go/pkg/component/wizard"
+	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
-	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/go-git/go-git/v5/plumbing/object"
 )
 
 const (
 	defaultScopePrompt           = "Enter scope (optional)"
 	defaultSubjectPrompt         = "Enter commit subject"
 	defaultBodyPrompt            = "Enter commit body (optional)"
+	defaultCoAuthorsPrompt       = "Select co-authors (optional)"
 	defaultConfirmPrompt         = "Commit this message?"
+	defaultConfirmYesLabel       = "Yes"
+	defaultConfirmEditLabel      = "Edit"
+	defaultConfirmBackLabel      = "Back"
+	defaultConfirmCancelLabel    = "Cancel"
 	defaultTypeSelectDisplaySize = 5
-	defaultPromptSeparator       = ": "
-	defaultIconCharQuestion      = "?"
-	defaultIconCharEntered       = "✓"
-)
-
-var (
-	quitKey = key.NewBinding(
-		key.WithKeys("ctrl+c"),
-		key.WithHelp("Ctrl+C", "quit"),
-	)
-	enterKey = key.NewBinding(
-		key.WithKeys("enter"),
-		key.WithHelp("Enter", "confirm"),
-	)
-	submitKey = key.NewBinding(
-		key.WithKeys("alt+enter", "ctrl+enter"),
-		key.WithHelp("⌘+Enter/Ctrl+Enter", "submit changes"),
-	)
-
-	fgColor = lipgloss.Color("#bb9af7")
-
-	defaultPromptStyle               = lipgloss.NewStyle().Bold(true)
-	defaultIconStyle                 = lipgloss.NewStyle().Foreground(fgColor).Bold(true).MarginRight(1)
-	defaultCommitMessagePreviewStyle = lipgloss.NewStyle().Bold(true).Padding(0, 2).Margin(0, 1).Border(lipgloss.RoundedBorder())
+	// defaultMultiSelectDisplaySize is the number of choices shown at once when several can be checked
+	defaultMultiSelectDisplaySize = 5
+	defaultPromptSeparator        = ": "
+	defaultIconCharQuestion       = "?"
+	defaultIconCharEntered        = "✓"
+	defaultCoAuthorLogLimit       = 500
 )
 
 // Stage represents the current stage of the commit message creation process
@@ -59,26 +49,40 @@ const (
 	StageBody         Stage = "body"
 	StageBreaking     Stage = "breaking"
 	StageFooter       Stage = "footer"
+	StageCoAuthors    Stage = "co_authors"
 	StageConfirm      Stage = "confirm"
 )
 
+// stageOrder is the order in which the stages are asked
+var stageOrder = []Stage{
+	StageTypeSelect,
+	StageScope,
+	StageTicketNumber,
+	StageSubject,
+	StageBody,
+	StageBreaking,
+	StageFooter,
+	StageCoAuthors,
+	StageConfirm,
+}
+
 // CommitData holds all the data collected from the user for generating commit message
 type CommitData struct {
-	Type            string // Selected commit type (feat, fix, etc.)
-	Scope           string // Optional scope (api, ui, etc.)
-	TicketNumber    string // Ticket number with prefix
-	Subject         string // Commit message subject
-	Body            string // Commit message body (multi-line)
-	BreakingChanges string // Breaking changes description
-	Footer          string // Footer information (validated format)
-	IsBreaking      bool   // Whether there are breaking changes
+	Type            string   `json:"type" yaml:"type"`                                   // Selected commit type (feat, fix, etc.)
+	Scope           string   `json:"scope" yaml:"scope,omitempty"`                       // Optional scope (api, ui, etc.)
+	TicketNumber    string   `json:"ticket_number" yaml:"ticket_number,omitempty"`       // Ticket number with prefix
+	Subject         string   `json:"subject" yaml:"subject"`                             // Commit message subject
+	Body            string   `json:"body" yaml:"body,omitempty"`                         // Commit message body (multi-line)
+	BreakingChanges string   `json:"breaking_changes" yaml:"breaking_changes,omitempty"` // Breaking changes description
+	Footer          string   `json:"footer" yaml:"footer,omitempty"`                     // Footer information (validated format)
+	CoAuthors       []string `json:"co_authors" yaml:"co_authors,omitempty"`             // Co-authors written as "Name <email>"
+	IsBreaking      bool     `json:"is_breaking" yaml:"is_breaking,omitempty"`           // Whether there are breaking changes
+
+	Custom map[string]any `json:"custom" yaml:"custom,omitempty"` // Answers to the custom questions keyed by their id
 }
 
-// GenerateCommitMessage generates a conventional commit message from the collected data
-func (cd CommitData) GenerateCommitMessage() string {
-	var parts []string
-
-	// Header: <type>(<scope>): <ticket_number> <subject>
+// Header builds the first line of the commit message: <type>(<scope>)!: <ticket_number> <subject>
+func (cd CommitData) Header() string {
 	header := cd.Type
 	if cd.Scope != "" {
 		header += "(" + cd.Scope + ")"
@@ -93,70 +97,85 @@ func (cd CommitData) GenerateCommitMessage() string {
 	}
 	header += " " + cd.Subject
 
-	parts = append(parts, header)
+	return header
+}
 
-	// Body with blank line
-	if cd.Body != "" {
-		parts = append(parts, "", cd.Body)
-	}
+// GenerateCommitMessage generates a conventional commit message from the collected data
+func (cd CommitData) GenerateCommitMessage() string {
+	return joinSections(cd.Header(), cd.Body, cd.footer())
+}
 
-	// Footers with blank line
-	hasFooter := false
+// footer はBREAKING CHANGE、フッター、共同作成者の順にフッターの行を連結する
+func (cd CommitData) footer() string {
+	var lines []string
 
 	// BREAKING CHANGE exception
 	if cd.BreakingChanges != "" {
-		if !hasFooter {
-			parts = append(parts, "")
-		}
-		parts = append(parts, "BREAKING CHANGE: "+cd.BreakingChanges)
-		hasFooter = true
+		lines = append(lines, "BREAKING CHANGE: "+cd.BreakingChanges)
 	}
-
 	if cd.Footer != "" {
-		if !hasFooter {
-			parts = append(parts, "")
-		}
-		parts = append(parts, cd.Footer)
+		lines = append(lines, cd.Footer)
+	}
+	for _, coAuthor := range cd.CoAuthors {
+		lines = append(lines, "Co-authored-by: "+coAuthor)
 	}
 
-	return strings.Join(parts, "\n")
+	return strings.Join(lines, "\n")
 }
 
 var _ tea.Model = Model{}
 
 // Model represents the main model for the git cz application
 type Model struct {
-	config       *config.Config
-	gitRepo      repo.GitRepository
-	currentStage Stage
-
-	// Individual models
-	typeSelect   selector.Model
-	scopeInput   textinput.Model
-	ticketNumber TicketNumberModel
-	subjectInput textinput.Model
-	bodyInput    textarea.Model
-	breaking     BreakingChangesModel
-	footer       FooterModel
-	confirm      confirm.Model
+	config  *config.Config
+	gitRepo repo.GitRepository
+	keys    KeyMap
+	styles  Styles
+
+	// Questions asked in order, one step per stage followed by the custom questions and the confirm stage
+	wizard wizard.Model
 
 	// Data collection
 	commitData CommitData
+	answered   map[Stage]bool // Stages answered before the wizard started
+	dryRun     bool           // Do not commit when confirmed
+	outcome    Outcome        // How the wizard ended
+	summary    commitSummary  // Summary of the created commit
+
+	// Editing the message in an external editor
+	editedMessage string      // Message edited in the editor, committed as is
+	violations    []Violation // Violations found in the edited message
+	editorErr     error       // Error from the last editor run
+
+	help   help.Model // Help line listing the keys of the current stage
+	width  int        // Terminal width, 0 until the first window size message
+	height int        // Terminal height, 0 until the first window size message
+
+	// Per-type question rules
+	seeded   map[Stage]string // Type defaults the inputs were seeded with
+	stageErr string           // Error that keeps the current stage from finishing
 }
 
-// NewModel creates a new main model for git cz
-func NewModel(cfg *config.Config, gitRepo repo.GitRepository) (Model, error) {
+// NewModel creates a new main model for git cz, styled with the renderer of the output it is drawn on
+func NewModel(cfg *config.Config, gitRepo repo.GitRepository, renderer *lipgloss.Renderer) (Model, error) {
+	keys, err := NewKeyMap(cfg.Keys)
+	if err != nil {
+		return Model{}, err
+	}
+	th := newTheme(cfg.Theme)
+	styles := NewStyles(renderer, th)
+
 	// Initialize type select model
 	size := min(len(cfg.Types), defaultTypeSelectDisplaySize)
 	selectItems := make([]selector.SelectItem, len(cfg.Types))
 	for i, t := range cfg.Types {
-		selectItems[i] = t
+		selectItems[i] = typeItem{tv: t}
 	}
 	typeSelect, err := selector.New(selectItems, size)
 	if err != nil {
 		return Model{}, err
 	}
-	typeSelect = typeSelect.SetCyclic(true).SetShowSelectedItem(true)
+	typeSelect = typeSelect.SetCyclic(true).SetShowSelectedItem(true).SetFilterable(true).SetHotkeys(true).SetKeyMap(keys.Selector).SetTheme(th).SetRenderer(renderer)
 	if cfg.Messages.Type != "" {
 		typeSelect.Prompt = cfg.Messages.Type
 	}
@@ -168,248 +187,548 @@ func NewModel(cfg *config.Config, gitRepo repo.GitRepository) (Model, error) {
 		scopePrompt = cfg.Messages.Scope
 	}
 	scopeInput.Prompt = scopePrompt + defaultPromptSeparator
-	scopeInput.PromptStyle = defaultPromptStyle
+	scopeInput.PromptStyle = styles.Prompt
 
 	// Initialize ticket number model
-	ticketNumber := NewTicketNumberModel(cfg.Messages.TicketNumber, cfg.TicketNumber, gitRepo)
+	ticketNumber := NewTicketNumberModel(cfg.Messages.TicketNumber, cfg.TicketNumber, gitRepo).SetKeyMap(keys).SetStyles(styles)
 
-	// Initialize subject input
-	subjectInput := textinput.New()
-	subjectPrompt := defaultSubjectPrompt
-	if cfg.Messages.Subject != "" {
-		subjectPrompt = cfg.Messages.Subject
-	}
-	subjectInput.Prompt = subjectPrompt + defaultPromptSeparator
-	subjectInput.PromptStyle = defaultPromptStyle
+	// Initialize subject model
+	subject := NewSubjectModel(cfg.Messages.Subject, cfg.Header).SetKeyMap(keys).SetStyles(styles)
 
-	// Initialize body input
-	bodyInput := textarea.New()
-	bodyPrompt := defaultBodyPrompt
-	if cfg.Messages.Body != "" {
-		bodyPrompt = cfg.Messages.Body
-	}
-	bodyInput.Prompt = bodyPrompt + defaultPromptSeparator
-	bodyInput.FocusedStyle = textarea.Style{
-		Prompt: defaultPromptStyle,
-	}
+	// Initialize body model
+	body := NewBodyModel(cfg.Messages.Body, cfg.Body).SetKeyMap(keys).SetStyles(styles)
 
 	// Initialize breaking changes model
-	breaking := NewBreakingChangesModel(cfg.Messages.BreakingConfirm, cfg.Messages.BreakingMessage)
+	breaking := NewBreakingChangesModel(cfg.Messages.BreakingConfirm, cfg.Messages.BreakingMessage).SetKeyMap(keys).SetTheme(th).SetRenderer(renderer)
 
 	// Initialize footer model
-	footerModel := NewFooterModel(cfg.Messages.Footer)
+	footerModel := NewFooterModel(cfg.Messages.Footer).SetKeyMap(keys).SetStyles(styles)
+
+	// Initialize co-authors model
+	coAuthorsPrompt := defaultCoAuthorsPrompt
+	if cfg.Messages.CoAuthors != "" {
+		coAuthorsPrompt = cfg.Messages.CoAuthors
+	}
+	// 履歴の作成者はInitで読み込み、それまでは設定の候補のみ表示する
+	coAuthorCandidates := collectCoAuthors(cfg.CoAuthors, nil)
+	coAuthors, err := newMultiSelector(coAuthorsPrompt, coAuthorCandidates, keys)
+	if err != nil {
+		return Model{}, err
+	}
+	coAuthors = coAuthors.SetTheme(th).SetRenderer(renderer)
 
 	// Initialize confirm model
-	confirmModel := confirm.New()
+	confirmModel, err := newConfirmChoice(cfg.Messages.ConfirmOptions, keys)
+	if err != nil {
+		return Model{}, err
+	}
+	confirmModel = confirmModel.SetTheme(th).SetRenderer(renderer)
 	confirmPrompt := defaultConfirmPrompt
 	if cfg.Messages.ConfirmCommit != "" {
 		confirmPrompt = cfg.Messages.ConfirmCommit
 	}
 	confirmModel.Prompt = confirmPrompt
 
+	entries := []wizard.Entry{
+		{ID: string(StageTypeSelect), Step: typeStep{model: typeSelect, keys: keys, types: cfg.Types}},
+		{ID: string(StageScope), Step: scopeStep{input: scopeInput, keys: keys}},
+		{ID: string(StageTicketNumber), Step: ticketStep{model: ticketNumber}},
+		{ID: string(StageSubject), Step: subjectStep{model: subject}},
+		{ID: string(StageBody), Step: bodyStep{model: body}},
+		{ID: string(StageBreaking), Step: breakingStep{model: breaking}},
+		{ID: string(StageFooter), Step: footerStep{model: footerModel}},
+		{ID: string(StageCoAuthors), Step: coAuthorsStep{model: coAuthors, keys: keys, candidates: coAuthorCandidates}},
+	}
+
+	// Initialize custom questions
+	for _, q := range cfg.Questions {
+		qm, err := NewQuestionModel(q)
+		if err != nil {
+			return Model{}, err
+		}
+		entries = append(entries, wizard.Entry{ID: string(questionStage(q)), Step: questionStep{model: qm.SetKeyMap(keys).SetTheme(th).SetRenderer(renderer).SetStyles(styles)}})
+	}
+	entries = append(entries, wizard.Entry{ID: string(StageConfirm), Step: confirmStep{model: confirmModel, keys: keys}})
+
+	w, err := wizard.New(entries...)
+	if err != nil {
+		return Model{}, err
+	}
+
 	model := Model{
-		config:       cfg,
-		gitRepo:      gitRepo,
-		currentStage: StageTypeSelect,
-		typeSelect:   typeSelect,
-		scopeInput:   scopeInput,
-		ticketNumber: ticketNumber,
-		subjectInput: subjectInput,
-		bodyInput:    bodyInput,
-		breaking:     breaking,
-		footer:       footerModel,
-		confirm:      confirmModel,
+		config:  cfg,
+		gitRepo: gitRepo,
+		keys:    keys,
+		styles:  styles,
+		help:    help.New(),
+		wizard:  w,
+		seeded:  map[Stage]string{},
 	}
 
 	return model, nil
 }
 
+// SetPrefill marks the stages answered by p as done so the wizard starts at the first unanswered stage
+func (m Model) SetPrefill(p Prefill) Model {
+	m.answered = map[Stage]bool{}
+	m.commitData = p.Data
+	for _, s := range p.Answered {
+		m.answered[s] = true
+		if step, ok := m.wizard.Step(string(s)).(stageStep); ok {
+			m.wizard = m.wizard.SetStep(string(s), step.load(p.Data))
+		}
+	}
+
+	m.enterStage(Stage(m.wizard.First(m.skipFunc())))
+	return m
+}
+
+// SetDryRun makes the confirm stage finish without committing
+func (m Model) SetDryRun(b bool) Model {
+	m.dryRun = b
+	return m
+}
+
+// GetOutcome returns how the wizard ended
+func (m Model) GetOutcome() Outcome {
+	return m.outcome
+}
+
+func (m Model) GetCommitData() CommitData {
+	return m.commitData
+}
+
+// GetCommitMessage returns the message to commit, preferring the one edited in the editor.
+// The message is written in the built-in format when the templates fail, which Validate reports before committing.
+func (m Model) GetCommitMessage() string {
+	if m.editedMessage != "" {
+		return m.editedMessage
+	}
+	message, err := m.commitData.FormatMessage(m.config)
+	if err != nil {
+		return m.commitData.GenerateCommitMessage()
+	}
+	return message
+}
+
+// currentStage returns the stage being asked
+func (m Model) currentStage() Stage {
+	return Stage(m.wizard.Current())
+}
+
+// typeValue returns the config of the selected type
+func (m Model) typeValue() config.TypeValue {
+	tv, _ := findTypeValue(m.config, m.commitData.Type)
+	return tv
+}
+
 func (m Model) Init() tea.Cmd {
-	return tea.Batch(
-		m.typeSelect.Init(),
-		m.ticketNumber.Init(),
-		m.breaking.Init(),
-		m.footer.Init(),
-		m.confirm.Init(),
-		textarea.Blink,
-	)
+	return tea.Batch(m.wizard.Init(), m.loadRecentAuthors())
 }
 
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case editorFinishedMsg:
+		m = m.handleEditorFinished(msg)
+		return m, nil
+	case recentAuthorsMsg:
+		return m.handleRecentAuthors(msg), nil
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.help.Width = msg.Width
+		m.resize()
+	case tea.MouseMsg:
+		// 各コンポーネントは自身の表示を原点とする座標で判定する
+		return m.updateStep(m.stageMouse(msg))
+	}
+
 	if msg, ok := msg.(tea.KeyMsg); ok {
-		if key.Matches(msg, quitKey) {
+		if key.Matches(msg, m.keys.Quit) {
 			return m, tea.Quit
 		}
+		// 文字の入力中は文字のキーを入力として扱う
+		if key.Matches(msg, m.keys.Help) && (msg.Type != tea.KeyRunes || !m.currentStep().typing()) {
+			m.help.ShowAll = !m.help.ShowAll
+			return m, nil
+		}
+		// 絞り込み中は戻らずに絞り込みを解除する
+		if m.currentStep().filtering() && key.Matches(msg, m.keys.Selector.ClearFilter) {
+			return m.updateStep(msg)
+		}
+		// 最初のステージでは各コンポーネントにキーを渡す
+		if key.Matches(msg, m.keys.Back) {
+			if prev, ok := m.wizard.Prev(m.skipFunc()); ok {
+				m.enterStage(Stage(prev))
+				return m, nil
+			}
+		}
+		if m.currentStage() == StageConfirm {
+			if stage, ok := m.jumpTarget(msg); ok {
+				// 事前に入力されたステージも編集できるようにする
+				delete(m.answered, stage)
+				m.enterStage(stage)
+				return m, nil
+			}
+		}
 	}
 
+	return m.updateStep(msg)
+}
+
+// updateStep は現在のステージにメッセージを渡し、回答された場合は次のステージへ進める
+func (m Model) updateStep(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
-	isFinished := false
-	switch m.currentStage {
-	case StageTypeSelect:
-		m.typeSelect, cmd = m.typeSelect.Update(msg)
-		isFinished = m.typeSelect.IsSelected()
-	case StageScope:
-		isFinished, m.scopeInput, cmd = handleTextInput(m.scopeInput, msg)
-	case StageTicketNumber:
-		m.ticketNumber, cmd = m.ticketNumber.Update(msg)
-		isFinished = m.ticketNumber.IsFinished()
-	case StageSubject:
-		isFinished, m.subjectInput, cmd = handleTextInput(m.subjectInput, msg)
-	case StageBody:
-		if msg, ok := msg.(tea.KeyMsg); ok {
-			if key.Matches(msg, submitKey) {
-				isFinished = true
-				break
-			}
+	m.wizard, cmd = m.wizard.Update(msg)
+	step := m.wizard.CurrentStep()
+	if !step.IsFinished() {
+		return m, cmd
+	}
+
+	if m.currentStage() == StageConfirm {
+		return m.confirmAction(step.Value().(confirmAction), cmd)
+	}
+
+	// Store data before moving to next stage
+	m.commitData = step.(stageStep).apply(m.commitData)
+	if m.currentStage() == StageTypeSelect {
+		// タイプを変更した場合に質問されなくなったステージの回答を残さない
+		m.commitData = m.commitData.clearSkippedForType(m.config, m.answered)
+	}
+
+	// タイプで必須とされた質問が空の場合は次のステージへ進まない
+	if v := m.commitData.lintRequired(m.config, m.currentStage()); len(v) > 0 {
+		m.enterStage(m.currentStage())
+		m.stageErr = v[0].Message
+		return m, cmd
+	}
+
+	next, ok := m.wizard.Next(m.skipFunc())
+	if !ok {
+		next = string(StageConfirm)
+	}
+	m.enterStage(Stage(next))
+	return m, cmd
+}
+
+// confirmAction は確認画面で選ばれた操作を行う
+func (m Model) confirmAction(action confirmAction, cmd tea.Cmd) (tea.Model, tea.Cmd) {
+	switch action {
+	case confirmYes:
+		return m.finish(true)
+	case confirmEdit:
+		// エディタから戻った後に選び直せるようにする
+		m.enterStage(StageConfirm)
+		return m.openEditor()
+	case confirmBack:
+		if prev, ok := m.wizard.Prev(m.skipFunc()); ok {
+			m.enterStage(Stage(prev))
+		} else {
+			m.enterStage(StageConfirm)
 		}
-		m.bodyInput, cmd = m.bodyInput.Update(msg)
-	case StageBreaking:
-		m.breaking, cmd = m.breaking.Update(msg)
-		isFinished = m.breaking.IsFinished()
-	case StageFooter:
-		m.footer, cmd = m.footer.Update(msg)
-		isFinished = m.footer.IsFinished()
-	case StageConfirm:
-		m.confirm, cmd = m.confirm.Update(msg)
-		isFinished = m.confirm.IsConfirmed()
-	}
-	if isFinished {
-		// Store data before moving to next stage
-		switch m.currentStage {
-		case StageTypeSelect:
-			m.commitData.Type = m.typeSelect.GetSelectedItem().(config.TypeValue).Value
-		case StageScope:
-			m.commitData.Scope = m.scopeInput.Value()
-		case StageTicketNumber:
-			m.commitData.TicketNumber = m.ticketNumber.GetValue()
-		case StageSubject:
-			m.commitData.Subject = m.subjectInput.Value()
-		case StageBody:
-			m.commitData.Body = m.bodyInput.Value()
-		case StageBreaking:
-			m.commitData.BreakingChanges = m.breaking.GetValue()
-			m.commitData.IsBreaking = m.breaking.HasBreakingChanges()
-		case StageFooter:
-			m.commitData.Footer = m.footer.GetValue()
-		case StageConfirm:
-			if m.confirm.GetValue() {
-				// User confirmed - generate and commit the message
-				commitMsg := m.commitData.GenerateCommitMessage()
-				if err := m.gitRepo.Commit(commitMsg); err != nil {
-					fmt.Printf("Error committing changes: %v\n", err)
-					return m, tea.Quit
-				}
-				return m, tea.Quit
-			} else {
-				// User declined
-				return m, tea.Quit
-			}
+		return m, cmd
+	}
+	return m.finish(false)
+}
+
+// finish は確認画面の回答に従ってコミットするか終了する
+func (m Model) finish(confirmed bool) (tea.Model, tea.Cmd) {
+	if !confirmed {
+		// User declined
+		m.outcome = Outcome{Kind: OutcomeCancelled}
+		return m, tea.Quit
+	}
+
+	// エディタで編集したメッセージは違反を確認済みとしてそのまま使用する
+	if m.editedMessage == "" {
+		if err := m.commitData.Validate(m.config); err != nil {
+			m.outcome = Outcome{Kind: OutcomeValidationFailed, Err: err}
+			return m, tea.Quit
 		}
+	}
+	if m.dryRun {
+		m.outcome = Outcome{Kind: OutcomeConfirmed}
+		return m, tea.Quit
+	}
+
+	// User confirmed - generate and commit the message
+	commitMsg := m.GetCommitMessage()
+	hash, err := m.gitRepo.Commit(commitMsg)
+	if err != nil {
+		m.outcome = Outcome{Kind: OutcomeGitError, Err: err}
+		return m, tea.Quit
+	}
+	m.outcome = Outcome{Kind: OutcomeCommitted, Hash: hash}
+	m.summary = newCommitSummary(m.gitRepo, hash, commitMsg, m.styles)
+	return m, tea.Quit
+}
 
-		m.currentStage = m.nextStage(m.currentStage)
-		switch m.currentStage {
-		case StageScope:
-			m.scopeInput.Focus()
-		case StageTicketNumber:
-			m.ticketNumber.Focus()
-		case StageSubject:
-			m.subjectInput.Focus()
-		case StageBody:
-			m.bodyInput.Focus()
-		case StageBreaking:
-			m.breaking.Focus()
-		case StageFooter:
-			m.footer.Focus()
+// loadSteps は各ステージの入力をコミットデータの値に合わせる
+func (m *Model) loadSteps(cd CommitData) {
+	for _, id := range m.wizard.IDs() {
+		if step, ok := m.wizard.Step(id).(stageStep); ok {
+			m.wizard = m.wizard.SetStep(id, step.load(cd))
 		}
 	}
+}
 
-	return m, cmd
+// enterStage は指定したステージへ移動し、入力済みの値を残したまま再び編集できる状態にする
+func (m *Model) enterStage(stage Stage) {
+	m.stageErr = ""
+	if stage != StageConfirm {
+		// 各ステージの入力からメッセージを作り直すためエディタでの編集は破棄する
+		m.editedMessage = ""
+		m.violations = nil
+		m.editorErr = nil
+	}
+
+	m.wizard = m.wizard.Jump(string(stage))
+	if step, ok := m.wizard.CurrentStep().(stageStep); ok {
+		m.wizard = m.wizard.SetStep(string(stage), step.prepare(m))
+	}
+	// 表示済みの回答の行数が変わるため、残りの高さを計算し直す
+	m.resize()
+}
+
+// seedDefault はタイプの既定値を返す
+// ユーザーが入力した値は残し、以前のタイプの既定値のままであれば置き換える
+func (m *Model) seedDefault(stage Stage, current, def string) string {
+	if current != "" && current != m.seeded[stage] {
+		return current
+	}
+	m.seeded[stage] = def
+	return def
+}
+
+// jumpTarget は確認画面で押された番号に対応するステージを返す
+func (m Model) jumpTarget(msg tea.KeyMsg) (Stage, bool) {
+	if msg.Type != tea.KeyRunes || len(msg.Runes) != 1 {
+		return "", false
+	}
+	n, err := strconv.Atoi(string(msg.Runes))
+	stages := m.progressStages()
+	if err != nil || n < 1 || n > len(stages) {
+		return "", false
+	}
+	return stages[n-1], true
 }
 
 func (m Model) View() string {
-	var sections []string
-	// Progress display
-	sections = append(sections, m.buildProgressView())
-	// Current stage view
-	sections = append(sections, defaultIconStyle.Render(defaultIconCharQuestion)+m.getStageView(m.currentStage))
+	// 終了後も残るように最後の画面として概要を表示する
+	if m.outcome.Kind == OutcomeCommitted {
+		return m.summary.View() + "\n"
+	}
 
-	return strings.Join(sections, "\n") + "\n"
+	view := m.buildProgressView() + "\n" + m.buildCurrentView()
+	if m.showPreview() {
+		view = m.buildPreviewView(view)
+	}
+	view += "\n\n" + m.help.View(m) + "\n"
+
+	// インラインで表示する場合も画面の上端から表示されるように端末の高さまで埋め、クリックの位置を対応させる
+	if m.config.Mouse && !m.config.AltScreen && m.height > 0 {
+		if lines := strings.Count(view, "\n") + 1; lines < m.height {
+			view += strings.Repeat("\n", m.height-lines)
+		}
+	}
+	return view
+}
+
+// buildCurrentView renders the current stage after the question icon, followed by the error keeping it from finishing
+func (m Model) buildCurrentView() string {
+	current := m.styles.Icon.Render(defaultIconCharQuestion) + m.getStageView(m.currentStage())
+	if m.stageErr != "" {
+		current += "\n" + m.styles.Error.Render("✕ "+m.stageErr)
+	}
+	return m.wrap(current)
+}
+
+// ShortHelp returns the keys of the current stage followed by the keys usable in every stage
+func (m Model) ShortHelp() []key.Binding {
+	return append(m.currentStep().ShortHelp(), m.globalKeys()...)
+}
+
+// FullHelp returns the keys of the current stage and the keys usable in every stage in columns
+func (m Model) FullHelp() [][]key.Binding {
+	return append(m.currentStep().FullHelp(), m.globalKeys())
+}
+
+// globalKeys は全てのステージで使えるキーを返す
+// 戻る先が無い場合は戻るキーを含めない。確認画面では戻るキーで戻る選択肢を選ぶ
+func (m Model) globalKeys() []key.Binding {
+	var bindings []key.Binding
+	if _, ok := m.wizard.Prev(m.skipFunc()); ok || m.currentStage() == StageConfirm {
+		bindings = append(bindings, m.keys.Back)
+	}
+	return append(bindings, m.keys.Help, m.keys.Quit)
+}
+
+// currentStep returns the step of the current stage
+func (m Model) currentStep() stageStep {
+	return m.wizard.CurrentStep().(stageStep)
 }
 
 func (m Model) buildProgressView() string {
 	var sections []string
-	s := StageTypeSelect
-	for {
-		if s == m.currentStage {
-			break
+	for i, s := range m.progressStages() {
+		// 確認画面ではステージへ移動するための番号を表示する
+		icon := defaultIconCharEntered
+		if m.currentStage() == StageConfirm {
+			icon = strconv.Itoa(i + 1)
+		}
+
+		if m.answered[s] {
+			sections = append(sections, m.styles.Icon.Render(icon)+m.getAnsweredView(s))
+		} else {
+			sections = append(sections, m.styles.Icon.Render(icon)+m.getStageView(s))
 		}
+	}
+	return m.wrap(strings.Join(sections, "\n"))
+}
+
+// progressStages returns the stages shown as done above the current stage
+func (m Model) progressStages() []Stage {
+	var stages []Stage
+	for _, id := range m.wizard.Completed(func(id string) bool {
+		return !m.answered[Stage(id)] && m.isStageSkipped(Stage(id))
+	}) {
+		stages = append(stages, Stage(id))
+	}
+	return stages
+}
 
-		sections = append(sections, defaultIconStyle.Render(defaultIconCharEntered)+m.getStageView(s))
-		s = m.nextStage(s)
+// getAnsweredView renders a stage answered before the wizard started
+func (m Model) getAnsweredView(stage Stage) string {
+	step, ok := m.wizard.Step(string(stage)).(stageStep)
+	if !ok {
+		return ""
 	}
-	return strings.Join(sections, "\n")
+	return m.styles.Prompt.Render(step.prompt()) + m.styles.Value.Render(m.commitData.stageValue(stage))
 }
 
 func (m Model) getStageView(stage Stage) string {
-	switch stage {
-	case StageTypeSelect:
-		return m.typeSelect.View()
-	case StageScope:
-		return m.scopeInput.View()
-	case StageTicketNumber:
-		return m.ticketNumber.View()
-	case StageSubject:
-		return m.subjectInput.View()
-	case StageBody:
-		return m.bodyInput.View()
-	case StageBreaking:
-		return m.breaking.View()
-	case StageFooter:
-		return m.footer.View()
-	case StageConfirm:
-		commitMessagePreview := m.commitData.GenerateCommitMessage()
-		m.confirm.Prompt += "\n" + defaultCommitMessagePreviewStyle.Render(commitMessagePreview)
-		return m.confirm.View()
-	default:
+	if stage != StageConfirm {
+		if step := m.wizard.Step(string(stage)); step != nil {
+			return step.View()
+		}
 		return ""
 	}
+
+	hint := fmt.Sprintf("Press %s to commit, %s to edit the message, 1-%d to edit an answer, %s to go back",
+		m.keys.Yes.Help().Key, m.keys.Edit.Help().Key, len(m.progressStages()), m.keys.Back.Help().Key)
+	return m.confirmView().View() + "\n" + m.styles.Info.Render(hint)
+}
+
+// confirmView returns a copy of the confirm model prompting with the whole message and its problems
+func (m Model) confirmView() choice.Model {
+	confirmModel := m.wizard.Step(string(StageConfirm)).(confirmStep).model
+	commitMessagePreview := m.GetCommitMessage()
+	previewStyle := m.styles.Message
+	if m.width > 0 && lipgloss.Width(previewStyle.Render(commitMessagePreview)) > m.width {
+		// 長い行は枠の中で折り返す
+		previewStyle = previewStyle.Width(m.width - previewStyle.GetHorizontalMargins() - previewStyle.GetHorizontalBorderSize())
+	}
+	confirmModel.Prompt += "\n" + previewStyle.Render(commitMessagePreview)
+	for _, v := range m.violations {
+		confirmModel.Prompt += "\n" + m.styles.violation(Violation{Message: v.String(), Warning: v.Warning})
+	}
+	if len(m.violations) > 0 {
+		confirmModel.Prompt += "\n" + m.styles.Info.Render(fmt.Sprintf("Press %s to edit again, or confirm to commit anyway", m.keys.Edit.Help().Key))
+	}
+	if m.editorErr != nil {
+		confirmModel.Prompt += "\n" + m.styles.Error.Render("✕ "+m.editorErr.Error())
+	}
+	if _, err := m.commitData.FormatMessage(m.config); err != nil && m.editedMessage == "" {
+		confirmModel.Prompt += "\n" + m.styles.Error.Render("✕ Failed to render the message: "+err.Error())
+	}
+	return confirmModel
 }
 
-func (m Model) nextStage(stage Stage) Stage {
+// skipFunc returns the stages the wizard does not ask
+func (m Model) skipFunc() wizard.SkipFunc {
+	return func(id string) bool {
+		return m.isStageSkipped(Stage(id))
+	}
+}
+
+// isStageSkipped reports whether the stage is not asked in the wizard
+func (m Model) isStageSkipped(stage Stage) bool {
+	if m.answered[stage] {
+		return true
+	}
+	if stage != StageTypeSelect && isSkippedForType(m.config, m.commitData.Type, stage) {
+		return true
+	}
+	if _, ok := m.wizard.Step(string(stage)).(questionStep); ok {
+		return !m.commitData.isQuestionAsked(m.config, string(stage))
+	}
+
 	switch stage {
-	case StageTypeSelect:
-		if !slices.Contains(m.config.SkipQuestions, string(StageScope)) {
-			return StageScope
-		}
-		fallthrough
-	case StageScope:
-		if m.config.TicketNumber.Enable {
-			return StageTicketNumber
-		}
-		fallthrough
 	case StageTicketNumber:
-		return StageSubject
-	case StageSubject:
-		if !slices.Contains(m.config.SkipQuestions, string(StageBody)) {
-			return StageBody
-		}
-		fallthrough
-	case StageBody:
-		if !slices.Contains(m.config.SkipQuestions, string(StageBreaking)) {
-			return StageBreaking
-		}
-		fallthrough
-	case StageBreaking:
-		if !slices.Contains(m.config.SkipQuestions, string(StageFooter)) {
-			return StageFooter
+		return !m.config.TicketNumber.Enable
+	case StageScope, StageBody, StageBreaking, StageFooter, StageCoAuthors:
+		return slices.Contains(m.config.SkipQuestions, string(stage))
+	}
+	return false
+}
+
+// recentAuthorsMsg is sent when the authors read from the history for the co-author candidates are loaded
+type recentAuthorsMsg struct {
+	authors []object.Signature
+	err     error
+}
+
+// loadRecentAuthors は起動を待たせないよう、共同作成者の候補となる履歴の作成者をバックグラウンドで読み込む
+func (m Model) loadRecentAuthors() tea.Cmd {
+	if m.gitRepo == nil || slices.Contains(m.config.SkipQuestions, string(StageCoAuthors)) {
+		return nil
+	}
+	gitRepo := m.gitRepo
+	return func() tea.Msg {
+		authors, err := gitRepo.GetRecentAuthors(defaultCoAuthorLogLimit)
+		return recentAuthorsMsg{authors: authors, err: err}
+	}
+}
+
+// handleRecentAuthors は履歴の作成者を共同作成者の候補に加える
+// 履歴が取得できない場合（コミットが無い場合など）は設定の候補のみ使用する
+func (m Model) handleRecentAuthors(msg recentAuthorsMsg) Model {
+	if msg.err != nil {
+		return m
+	}
+	id := string(StageCoAuthors)
+	step := m.wizard.Step(id).(coAuthorsStep)
+	m.wizard = m.wizard.SetStep(id, step.setCandidates(collectCoAuthors(m.config.CoAuthors, msg.authors)))
+	m.resize()
+	return m
+}
+
+// collectCoAuthors は履歴の作成者と設定から共同作成者の候補を集める
+// 履歴から取得した候補は最近使われた順に並び、設定の候補はその後ろに続く
+func collectCoAuthors(configured []config.CoAuthor, authors []object.Signature) []string {
+	var candidates []string
+	seen := map[string]bool{}
+	add := func(name, email string) {
+		id := strings.ToLower(email)
+		if seen[id] {
+			return
 		}
+		seen[id] = true
+		candidates = append(candidates, config.CoAuthor{Name: name, Email: email}.String())
+	}
+
+	for _, a := range authors {
+		add(a.Name, a.Email)
+	}
+	for _, c := range configured {
+		add(c.Name, c.Email)
 	}
-	return StageConfirm
+	return candidates
 }
 
-func handleTextInput(m textinput.Model, msg tea.Msg) (bool, textinput.Model, tea.Cmd) {
+func handleTextInput(m textinput.Model, enter key.Binding, msg tea.Msg) (bool, textinput.Model, tea.Cmd) {
 	if msg, ok := msg.(tea.KeyMsg); ok {
-		if key.Matches(msg, enterKey) {
+		if key.Matches(msg, enter) {
 			return true, m, nil
 		}
 	}
@@ -0,0 +1,27 @@
+package model
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// stageMouse converts the terminal coordinates of a mouse event into ones relative to the current stage's view
+func (m Model) stageMouse(msg tea.MouseMsg) tea.MouseMsg {
+	above, total := m.viewHeights()
+	// 端末の高さを超える画面は上の行が表示されない
+	if m.height > 0 && total > m.height {
+		msg.Y += total - m.height
+	}
+	msg.Y -= above
+
+	if m.currentStage() == StageConfirm {
+		// 確認画面ではプロンプトにメッセージ全体を加えて表示している
+		step := m.wizard.Step(string(StageConfirm)).(confirmStep)
+		msg.Y -= lipgloss.Height(m.confirmView().Prompt) - lipgloss.Height(step.model.Prompt)
+	}
+	// 最初の行だけアイコンの後に表示している
+	if msg.Y == 0 {
+		msg.X -= lipgloss.Width(m.styles.Icon.Render(defaultIconCharQuestion))
+	}
+	return msg
+}
@@ -0,0 +1,79 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// findInView は画面上で文字列が表示されている座標を返す
+func findInView(t *testing.T, m Model, s string) (int, int) {
+	t.Helper()
+	for y, line := range strings.Split(ansi.Strip(m.View()), "\n") {
+		if strings.TrimSpace(line) == s {
+			return ansi.StringWidth(line[:strings.Index(line, s)]), y
+		}
+	}
+	t.Fatalf("%q is not in the view:\n%s", s, m.View())
+	return 0, 0
+}
+
+func TestModel_Mouse(t *testing.T) {
+	tests := []struct {
+		name      string
+		height    int
+		target    string
+		button    tea.MouseButton
+		wantStage Stage
+		wantType  string
+	}{
+		{
+			name:      "[正常系] クリックしたタイプを選択",
+			target:    "2 fix",
+			button:    tea.MouseButtonLeft,
+			wantStage: StageScope,
+			wantType:  "fix: :bug:",
+		},
+		{
+			name:   "[正常系] 端末の高さを超えて上の行が隠れている場合",
+			height: 10,
+			// 一覧は1件まで縮めても端末に収まらない
+			target:    "> 1 feat",
+			button:    tea.MouseButtonLeft,
+			wantStage: StageScope,
+			wantType:  "feat: :sparkles:",
+		},
+		{
+			name:      "[正常系] ホイールでカーソルを移動",
+			target:    "2 fix",
+			button:    tea.MouseButtonWheelDown,
+			wantStage: StageTypeSelect,
+			wantType:  "fix: :bug:",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := createTestPreviewModel(t, createTestConfig())
+			updated, _ := m.Update(tea.WindowSizeMsg{Width: 100, Height: tt.height})
+			m = updated.(Model)
+
+			x, y := findInView(t, m, tt.target)
+			if lines := strings.Count(m.View(), "\n") + 1; tt.height > 0 && lines > tt.height {
+				// 画面に表示されている行の位置に直す
+				y -= lines - tt.height
+			}
+			updated, _ = m.Update(tea.MouseMsg{X: x, Y: y, Action: tea.MouseActionPress, Button: tt.button})
+			m = updated.(Model)
+
+			if got := m.currentStage(); got != tt.wantStage {
+				t.Errorf("currentStage() = %q, want %q", got, tt.wantStage)
+			}
+			if got := m.previewData().Type; got != tt.wantType {
+				t.Errorf("Type = %q, want %q", got, tt.wantType)
+			}
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package model
+
+// OutcomeKind describes how the wizard ended
+type OutcomeKind int
+
+const (
+	OutcomeCancelled        OutcomeKind = iota // The user quit or declined the commit
+	OutcomeCommitted                           // The commit was created
+	OutcomeConfirmed                           // The message was confirmed without committing (dry run)
+	OutcomeValidationFailed                    // The message does not satisfy the config
+	OutcomeGitError                            // Creating the commit failed
+)
+
+// Outcome is the result carried by the final model
+type Outcome struct {
+	Kind OutcomeKind
+	Hash string // Hash of the created commit
+	Err  error  // Cause of a validation or git failure
+}
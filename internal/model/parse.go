@@ -0,0 +1,133 @@
+package model
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/cffnpwr/git-cz-go/config"
+)
+
+const (
+	breakingChangeTrailer = "BREAKING CHANGE: "
+	coAuthorTrailer       = "Co-authored-by: "
+)
+
+// headerPattern
+// `(<scope>)`と`!`が省略可能な、タイプより後ろのヘッダー
+var headerPattern = regexp.MustCompile(`^(?:\(([^)]*)\))?(!)?:\s*(.*)$`)
+
+// fallbackTypePattern
+// 設定に存在しないタイプの場合はヘッダー先頭のトークンをタイプとみなす
+var fallbackTypePattern = regexp.MustCompile(`^[^\s(!:]+`)
+
+var paragraphSeparatorPattern = regexp.MustCompile(`\n{2,}`)
+
+// ParseCommitMessage splits a commit message written in the format of GenerateCommitMessage back into commit data.
+// Lines starting with `#` are treated as comments and removed, as git does.
+func ParseCommitMessage(cfg *config.Config, message string) CommitData {
+	message = StripComments(message)
+	paragraphs := splitParagraphs(message)
+	if len(paragraphs) == 0 {
+		return CommitData{}
+	}
+
+	// ヘッダーの直後に空行が無い場合は続く行を本文とする
+	header, rest, _ := strings.Cut(paragraphs[0], "\n")
+	cd := parseHeader(cfg, header)
+	paragraphs = paragraphs[1:]
+	if rest != "" {
+		paragraphs = append([]string{rest}, paragraphs...)
+	}
+
+	// 最後の段落がトレーラーで始まる場合はフッターとして扱う
+	if n := len(paragraphs); n > 0 && isTrailer(strings.SplitN(paragraphs[n-1], "\n", 2)[0]) {
+		cd.parseTrailers(paragraphs[n-1])
+		paragraphs = paragraphs[:n-1]
+	}
+	cd.Body = strings.Join(paragraphs, "\n\n")
+
+	return cd
+}
+
+// StripComments removes comment lines and surrounding blank lines from a commit message
+func StripComments(message string) string {
+	var lines []string
+	for _, l := range strings.Split(message, "\n") {
+		if strings.HasPrefix(l, "#") {
+			continue
+		}
+		lines = append(lines, strings.TrimRight(l, " \t\r"))
+	}
+	return strings.Trim(strings.Join(lines, "\n"), "\n")
+}
+
+func parseHeader(cfg *config.Config, header string) CommitData {
+	var cd CommitData
+
+	// 絵文字などを含むタイプに対応するため、設定に一致する最も長いタイプを優先する
+	for _, t := range cfg.Types {
+		if strings.HasPrefix(header, t.Value) && len(t.Value) > len(cd.Type) {
+			cd.Type = t.Value
+		}
+	}
+	if cd.Type == "" {
+		cd.Type = fallbackTypePattern.FindString(header)
+	}
+
+	match := headerPattern.FindStringSubmatch(strings.TrimPrefix(header, cd.Type))
+	if match == nil {
+		// ヘッダーの形式が崩れている場合は全体を件名とする
+		return CommitData{Subject: header}
+	}
+	cd.Scope = match[1]
+	cd.IsBreaking = match[2] != ""
+	cd.Subject = match[3]
+
+	if token, rest, ok := strings.Cut(cd.Subject, " "); ok && isTicketNumber(cfg.TicketNumber, token) {
+		cd.TicketNumber = token
+		cd.Subject = strings.TrimSpace(rest)
+	}
+	return cd
+}
+
+// isTicketNumber はヘッダーのトークンがチケット番号かどうかを判定する
+func isTicketNumber(tnCfg config.TicketNumber, token string) bool {
+	if !tnCfg.Enable {
+		return false
+	}
+	if tnCfg.Prefix != "" {
+		return strings.HasPrefix(token, tnCfg.Prefix)
+	}
+	return tnCfg.MatchPattern != nil && (*regexp.Regexp)(tnCfg.MatchPattern).MatchString(token)
+}
+
+func isTrailer(line string) bool {
+	return strings.HasPrefix(line, breakingChangeTrailer) || footerStartPattern.MatchString(line)
+}
+
+// parseTrailers はフッターの各行を破壊的変更、共同作成者、その他のフッターに振り分ける
+func (cd *CommitData) parseTrailers(paragraph string) {
+	var footers []string
+	for _, l := range strings.Split(paragraph, "\n") {
+		switch {
+		case strings.HasPrefix(l, breakingChangeTrailer):
+			cd.BreakingChanges = strings.TrimPrefix(l, breakingChangeTrailer)
+			cd.IsBreaking = true
+		case strings.HasPrefix(strings.ToLower(l), strings.ToLower(coAuthorTrailer)):
+			cd.CoAuthors = append(cd.CoAuthors, strings.TrimSpace(l[len(coAuthorTrailer):]))
+		default:
+			footers = append(footers, l)
+		}
+	}
+	cd.Footer = strings.Join(footers, "\n")
+}
+
+func splitParagraphs(message string) []string {
+	var paragraphs []string
+	for _, p := range paragraphSeparatorPattern.Split(message, -1) {
+		if p = strings.Trim(p, "\n"); p != "" {
+			paragraphs = append(paragraphs, p)
+		}
+	}
+	return paragraphs
+}
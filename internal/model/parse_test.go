@@ -0,0 +1,109 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseCommitMessage(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		want    CommitData
+	}{
+		{
+			name:    "[正常系] ヘッダーのみ",
+			message: "fix: :bug:: #42 fix crash",
+			want: CommitData{
+				Type:         "fix: :bug:",
+				TicketNumber: "#42",
+				Subject:      "fix crash",
+			},
+		},
+		{
+			name: "[正常系] 全ての項目",
+			message: "feat: :sparkles:(api)!: #123 add endpoint\n\n" +
+				"first paragraph\n\nsecond paragraph\n\n" +
+				"BREAKING CHANGE: drop v1\nRefs: #100\nCo-authored-by: Alice <alice@example.com>\n",
+			want: CommitData{
+				Type:            "feat: :sparkles:",
+				Scope:           "api",
+				TicketNumber:    "#123",
+				Subject:         "add endpoint",
+				Body:            "first paragraph\n\nsecond paragraph",
+				BreakingChanges: "drop v1",
+				Footer:          "Refs: #100",
+				CoAuthors:       []string{"Alice <alice@example.com>"},
+				IsBreaking:      true,
+			},
+		},
+		{
+			name:    "[正常系] コメント行と末尾の空行を除去",
+			message: "docs: :memo:: #1 update readme\n\nbody text\n\n# Please enter the commit message\n#\n\n",
+			want: CommitData{
+				Type:         "docs: :memo:",
+				TicketNumber: "#1",
+				Subject:      "update readme",
+				Body:         "body text",
+			},
+		},
+		{
+			name:    "[正常系] ヘッダー直後の行は本文",
+			message: "docs: :memo:: #1 update readme\nbody text",
+			want: CommitData{
+				Type:         "docs: :memo:",
+				TicketNumber: "#1",
+				Subject:      "update readme",
+				Body:         "body text",
+			},
+		},
+		{
+			name:    "[異常系] 設定に無いタイプ",
+			message: "perf: speed up",
+			want: CommitData{
+				Type:    "perf",
+				Subject: "speed up",
+			},
+		},
+		{
+			name:    "[異常系] 形式に従わないヘッダー",
+			message: "just some text",
+			want: CommitData{
+				Subject: "just some text",
+			},
+		},
+		{
+			name:    "[異常系] コメントのみ",
+			message: "# nothing here\n",
+			want:    CommitData{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseCommitMessage(createTestConfig(), tt.message)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ParseCommitMessage() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParseCommitMessage_RoundTrip(t *testing.T) {
+	data := CommitData{
+		Type:            "fix: :bug:",
+		Scope:           "ui",
+		TicketNumber:    "#7",
+		Subject:         "keep focus",
+		Body:            "details",
+		BreakingChanges: "removed option",
+		Footer:          "Refs: #6",
+		IsBreaking:      true,
+	}
+
+	got := ParseCommitMessage(createTestConfig(), data.GenerateCommitMessage())
+	if diff := cmp.Diff(data, got); diff != "" {
+		t.Errorf("ParseCommitMessage() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,20 @@
+package model
+
+import (
+	"github.com/cffnpwr/git-cz-go/config"
+)
+
+// Prefill holds answers given before the wizard starts, e.g. from command line flags
+type Prefill struct {
+	Data     CommitData
+	Answered []Stage
+}
+
+// Validate checks the answered stages only
+func (p Prefill) Validate(cfg *config.Config) error {
+	var violations []Violation
+	for _, stage := range p.Answered {
+		violations = append(violations, p.Data.lintStage(cfg, stage)...)
+	}
+	return violationsError(violations)
+}
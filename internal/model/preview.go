@@ -0,0 +1,93 @@
+package model
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/cffnpwr/git-cz-go/config"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/mattn/go-runewidth"
+)
+
+const (
+	defaultHeaderMaxLength = 72
+	previewTitle           = "Preview"
+	// previewMinPanelWidth is the narrowest panel shown beside the questions before falling back to the bottom
+	previewMinPanelWidth = 30
+	// previewCompactWidth is the terminal width below which the preview collapses to a single line
+	previewCompactWidth = 50
+)
+
+// headerStages are the stages whose input is shown in the preview while it is being entered
+var headerStages = []Stage{StageTypeSelect, StageScope, StageTicketNumber, StageSubject}
+
+// previewData は入力済みのデータに現在のステージで入力中の値を反映する
+func (m Model) previewData() CommitData {
+	cd := m.commitData
+	if step, ok := m.wizard.CurrentStep().(stageStep); ok && slices.Contains(headerStages, m.currentStage()) {
+		cd = step.apply(cd)
+	}
+	return cd
+}
+
+// headerMaxLength returns the configured header length limit
+func (m Model) headerMaxLength() int {
+	if r := m.config.Header.MaxLength; r != nil {
+		return r.Value
+	}
+	return defaultHeaderMaxLength
+}
+
+// buildPreviewCounter renders the header width in display columns, in red when it exceeds the limit
+func (m Model) buildPreviewCounter(header string) string {
+	length, limit := runewidth.StringWidth(header), m.headerMaxLength()
+	counter := fmt.Sprintf("%d/%d", length, limit)
+	if length > limit {
+		return m.styles.Error.Render(counter)
+	}
+	return m.styles.Info.Render(counter)
+}
+
+// showPreview reports whether the live preview is shown with the current question
+func (m Model) showPreview() bool {
+	// 確認画面ではメッセージ全体を表示しているためプレビューは不要
+	return m.currentStage() != StageConfirm && m.config.Preview.Position != config.PreviewPositionNone
+}
+
+// buildPreviewView renders the live preview next to or below the current question
+func (m Model) buildPreviewView(main string) string {
+	panel, beside := m.buildPreviewPanel(lipgloss.Width(main))
+	if beside {
+		return lipgloss.JoinHorizontal(lipgloss.Top, main, " ", panel)
+	}
+	return main + "\n" + panel
+}
+
+// buildPreviewPanel renders the live preview for questions of mainWidth, reporting whether it is shown beside them
+func (m Model) buildPreviewPanel(mainWidth int) (string, bool) {
+	header := m.previewData().Header()
+	counter := m.buildPreviewCounter(header)
+
+	// 狭い端末ではヘッダーのみを1行で表示する
+	if m.width > 0 && m.width < previewCompactWidth {
+		title := m.styles.PreviewTitle.Render(previewTitle + ": ")
+		line := ansi.Truncate(header, m.width-lipgloss.Width(title)-lipgloss.Width(counter)-1, "…")
+		return title + line + " " + counter, false
+	}
+
+	content := m.styles.PreviewTitle.Render(previewTitle) + " " + counter + "\n" + header
+	if m.config.Preview.Position == config.PreviewPositionRight && m.width > 0 {
+		// 質問との間の空白と枠線の分を除いた幅に収める
+		width := m.width - mainWidth - m.styles.PreviewPanel.GetHorizontalBorderSize() - 1
+		if width >= previewMinPanelWidth {
+			return m.styles.PreviewPanel.Width(width).Render(content), true
+		}
+	}
+
+	panel := m.styles.PreviewPanel
+	if m.width > 0 {
+		panel = panel.Width(m.width - m.styles.PreviewPanel.GetHorizontalBorderSize())
+	}
+	return panel.Render(content), false
+}
@@ -0,0 +1,130 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cffnpwr/git-cz-go/config"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func createTestPreviewModel(t *testing.T, cfg *config.Config) Model {
+	t.Helper()
+	cfg.SkipQuestions = config.SkipQuestions{"co_authors"}
+	m, err := NewModel(cfg, nil, lipgloss.DefaultRenderer())
+	if err != nil {
+		t.Fatalf("NewModel() error = %v", err)
+	}
+	return m
+}
+
+func TestModel_PreviewData(t *testing.T) {
+	m := createTestPreviewModel(t, createTestConfig())
+
+	// 選択前でもカーソル位置のタイプが反映される
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m = updated.(Model)
+	if got, want := m.previewData().Header(), "fix: :bug:: "; got != want {
+		t.Errorf("Header() = %q, want %q", got, want)
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	updated, _ = updated.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("ui")})
+	m = updated.(Model)
+	if got, want := m.previewData().Header(), "fix: :bug:(ui): "; got != want {
+		t.Errorf("Header() = %q, want %q", got, want)
+	}
+}
+
+func TestModel_BuildPreviewView(t *testing.T) {
+	tests := []struct {
+		name      string
+		maxLength *config.Rule[int]
+		position  string
+		width     int
+		wantLines int
+		want      string
+	}{
+		{
+			name:      "[正常系] 下部にパネルを表示",
+			width:     100,
+			wantLines: 5,
+			want:      "Preview 18/72",
+		},
+		{
+			name:      "[正常系] 上限を超えた長さ",
+			maxLength: &config.Rule[int]{Value: 10},
+			width:     100,
+			wantLines: 5,
+			want:      "Preview 18/10",
+		},
+		{
+			name:      "[正常系] 右側に表示できる幅が無い場合は下部に表示",
+			position:  config.PreviewPositionRight,
+			width:     60,
+			wantLines: 5,
+			want:      "Preview 18/72",
+		},
+		{
+			name:      "[正常系] 右側にパネルを表示",
+			position:  config.PreviewPositionRight,
+			width:     100,
+			wantLines: 4,
+			want:      "Preview 18/72",
+		},
+		{
+			name:      "[正常系] 狭い端末では1行で表示",
+			width:     40,
+			wantLines: 2,
+			want:      "Preview: feat: :sparkles::  18/72",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createTestConfig()
+			cfg.Header.MaxLength = tt.maxLength
+			cfg.Preview.Position = tt.position
+			m := createTestPreviewModel(t, cfg)
+			m.width = tt.width
+
+			got := m.buildPreviewView("? " + strings.Repeat("x", 40))
+			if n := len(strings.Split(got, "\n")); n != tt.wantLines {
+				t.Errorf("buildPreviewView() has %d lines, want %d:\n%s", n, tt.wantLines, got)
+			}
+			if !strings.Contains(got, tt.want) {
+				t.Errorf("buildPreviewView() = \n%s\nwant to contain %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestModel_BuildPreviewCounter(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   string
+	}{
+		{
+			name:   "[正常系] 半角の文字数",
+			header: "fix: typo",
+			want:   "9/72",
+		},
+		{
+			name:   "[正常系] 全角の文字と絵文字は2桁として数える",
+			header: "feat: ✨ 日本語を追加",
+			want:   "21/72",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := createTestPreviewModel(t, createTestConfig())
+			if got := ansi.Strip(m.buildPreviewCounter(tt.header)); got != tt.want {
+				t.Errorf("buildPreviewCounter() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
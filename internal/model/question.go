@@ -0,0 +1,364 @@
+package model
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/cffnpwr/git-cz-go/config"
+	"github.com/cffnpwr/git-cz-go/pkg/component/confirm"
+	"github.com/cffnpwr/git-cz-go/pkg/component/selector"
+	"github.com/cffnpwr/git-cz-go/pkg/theme"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// choiceItem は選択肢を selector で表示するための項目
+type choiceItem string
+
+func (c choiceItem) String() string {
+	return string(c)
+}
+
+// choiceItems は選択肢を selector の項目に変換する
+func choiceItems(choices []string) []selector.SelectItem {
+	items := make([]selector.SelectItem, len(choices))
+	for i, c := range choices {
+		items[i] = choiceItem(c)
+	}
+	return items
+}
+
+// newMultiSelector は入力した文字列で絞り込みながら複数の選択肢をチェックする selector を作る
+func newMultiSelector(prompt string, choices []string, keys KeyMap) (selector.Model, error) {
+	s, err := selector.New(choiceItems(choices), defaultMultiSelectDisplaySize)
+	if err != nil {
+		return selector.Model{}, err
+	}
+	s = s.SetMultiSelect(true).SetFilterable(true).SetShowSelectedItem(true).SetKeyMap(keys.multiSelector())
+	s.Prompt = prompt
+	return s, nil
+}
+
+// checkValues は選択肢のうち values に含まれるものをチェックする
+func checkValues(s selector.Model, choices, values []string) selector.Model {
+	var indexes []int
+	for _, v := range values {
+		if i := slices.Index(choices, v); i >= 0 {
+			indexes = append(indexes, i)
+		}
+	}
+	return s.SetCheckedItems(indexes...)
+}
+
+// checkedValues は selector でチェックされた選択肢を元の並び順で返す
+func checkedValues(s selector.Model) []string {
+	var values []string
+	for _, item := range s.GetCheckedItems() {
+		values = append(values, item.String())
+	}
+	return values
+}
+
+// QuestionModel は設定で定義されたカスタム質問を種類に応じた入力で尋ねるモデル
+type QuestionModel struct {
+	question config.Question
+
+	// 質問の種類に応じていずれか1つを使用する
+	input    textinput.Model
+	textarea textarea.Model
+	choices  selector.Model // select と multiselect で使う
+	confirm  confirm.Model
+
+	keys       KeyMap
+	styles     Styles
+	finished   bool
+	violations []Violation
+}
+
+func NewQuestionModel(q config.Question) (QuestionModel, error) {
+	m := QuestionModel{question: q, keys: DefaultKeyMap}
+	prompt := m.prompt()
+
+	switch q.GetKind() {
+	case config.QuestionKindText:
+		m.input = textinput.New()
+		m.input.Prompt = prompt + defaultPromptSeparator
+	case config.QuestionKindTextarea:
+		m.textarea = textarea.New()
+		m.textarea.Prompt = prompt + defaultPromptSeparator
+	case config.QuestionKindSelect:
+		items := choiceItems(q.Choices)
+		choices, err := selector.New(items, min(len(items), defaultTypeSelectDisplaySize))
+		if err != nil {
+			return QuestionModel{}, err
+		}
+		m.choices = choices.SetCyclic(true).SetHotkeys(true)
+		m.choices.Prompt = prompt
+	case config.QuestionKindConfirm:
+		m.confirm = confirm.New()
+	case config.QuestionKindMultiselect:
+		choices, err := newMultiSelector(prompt, q.Choices, m.keys)
+		if err != nil {
+			return QuestionModel{}, err
+		}
+		m.choices = choices
+	}
+	return m.SetStyles(NewStyles(lipgloss.DefaultRenderer(), theme.Default)), nil
+}
+
+// SetKeyMap sets the keys of the input used by the question
+func (m QuestionModel) SetKeyMap(km KeyMap) QuestionModel {
+	m.keys = km
+	if m.question.GetKind() == config.QuestionKindMultiselect {
+		m.choices = m.choices.SetKeyMap(km.multiSelector())
+	} else {
+		m.choices = m.choices.SetKeyMap(km.Selector)
+	}
+	m.confirm = m.confirm.SetKeyMap(km.Confirm)
+	return m
+}
+
+// SetSize fits the input used by the question into the space left for it
+func (m QuestionModel) SetSize(width, height int) QuestionModel {
+	switch m.question.GetKind() {
+	case config.QuestionKindText:
+		m.input = fitInput(m.input, width)
+	case config.QuestionKindTextarea:
+		// ヒントと違反の行を残す
+		m.textarea = fitTextarea(m.textarea, width, height, 2)
+	case config.QuestionKindSelect, config.QuestionKindMultiselect:
+		m.choices = m.choices.SetSize(width, height)
+	case config.QuestionKindConfirm:
+		m.confirm = m.confirm.SetWidth(width)
+	}
+	return m
+}
+
+// SetStyles sets the styles of the prompt, the answer and the messages under the input
+func (m QuestionModel) SetStyles(s Styles) QuestionModel {
+	m.styles = s
+	switch m.question.GetKind() {
+	case config.QuestionKindText:
+		m.input.PromptStyle = s.Prompt
+	case config.QuestionKindTextarea:
+		m.textarea.FocusedStyle = textarea.Style{
+			Prompt: s.Prompt,
+		}
+	case config.QuestionKindConfirm:
+		m.confirm.Prompt = s.Prompt.Render(m.prompt())
+	}
+	return m
+}
+
+// SetTheme sets the colors of the selector and the confirmation used by the question
+func (m QuestionModel) SetTheme(t theme.Theme) QuestionModel {
+	m.choices = m.choices.SetTheme(t)
+	m.confirm = m.confirm.SetTheme(t)
+	return m
+}
+
+// SetRenderer sets the renderer the selector and the confirmation used by the question are styled with
+func (m QuestionModel) SetRenderer(r *lipgloss.Renderer) QuestionModel {
+	m.choices = m.choices.SetRenderer(r)
+	m.confirm = m.confirm.SetRenderer(r)
+	return m
+}
+
+// ShortHelp returns the keys of the input used by the question, implementing help.KeyMap
+func (m QuestionModel) ShortHelp() []key.Binding {
+	switch m.question.GetKind() {
+	case config.QuestionKindTextarea:
+		return []key.Binding{m.keys.Submit}
+	case config.QuestionKindSelect:
+		km := m.keys.Selector
+		return []key.Binding{km.Up, km.Down, km.Select}
+	case config.QuestionKindConfirm:
+		km := m.keys.Confirm
+		return []key.Binding{km.Toggle, km.Affirmative, km.Negative, km.Select}
+	case config.QuestionKindMultiselect:
+		return m.keys.multiSelectShortHelp(m.choices.IsFiltering())
+	default:
+		return []key.Binding{m.keys.Enter}
+	}
+}
+
+// FullHelp returns the keys of the input used by the question, implementing help.KeyMap
+func (m QuestionModel) FullHelp() [][]key.Binding {
+	if m.question.GetKind() == config.QuestionKindMultiselect {
+		return m.keys.multiSelectFullHelp()
+	}
+	return [][]key.Binding{m.ShortHelp()}
+}
+
+// typing reports whether the answer or the filter of the choices is being typed as text
+func (m QuestionModel) typing() bool {
+	switch m.question.GetKind() {
+	case config.QuestionKindText, config.QuestionKindTextarea:
+		return true
+	case config.QuestionKindMultiselect:
+		return m.choices.IsFiltering()
+	}
+	return false
+}
+
+func (m QuestionModel) prompt() string {
+	if m.question.Prompt != "" {
+		return m.question.Prompt
+	}
+	return m.question.ID
+}
+
+func (m QuestionModel) GetPrompt() string {
+	return m.prompt() + defaultPromptSeparator
+}
+
+// GetValue returns the answer as a string, a bool for confirm or a []string for multiselect
+func (m QuestionModel) GetValue() any {
+	switch m.question.GetKind() {
+	case config.QuestionKindTextarea:
+		return strings.TrimSpace(m.textarea.Value())
+	case config.QuestionKindSelect:
+		if item := m.choices.GetCurrentItem(); item != nil {
+			return item.String()
+		}
+		return ""
+	case config.QuestionKindConfirm:
+		return m.confirm.GetValue()
+	case config.QuestionKindMultiselect:
+		return checkedValues(m.choices)
+	default:
+		return strings.TrimSpace(m.input.Value())
+	}
+}
+
+func (m QuestionModel) IsFinished() bool {
+	return m.finished
+}
+
+// SetValue restores a previous answer, e.g. one read from an answers file
+func (m QuestionModel) SetValue(v any) QuestionModel {
+	v = normalizeAnswer(m.question, v)
+	switch m.question.GetKind() {
+	case config.QuestionKindText:
+		m.input.SetValue(v.(string))
+	case config.QuestionKindTextarea:
+		m.textarea.SetValue(v.(string))
+	case config.QuestionKindSelect:
+		m.choices = m.choices.SetCursor(slices.Index(m.question.Choices, v.(string)))
+	case config.QuestionKindConfirm:
+		m.confirm = m.confirm.SetValue(v.(bool))
+	case config.QuestionKindMultiselect:
+		m.choices = checkValues(m.choices, m.question.Choices, v.([]string))
+	}
+	return m
+}
+
+// Reset makes the model editable again while keeping the answer
+func (m QuestionModel) Reset() QuestionModel {
+	m.finished = false
+	m.choices = m.choices.Reset()
+	m.confirm = m.confirm.Reset()
+	return m
+}
+
+func (m *QuestionModel) Focus() {
+	switch m.question.GetKind() {
+	case config.QuestionKindText:
+		m.input.Focus()
+	case config.QuestionKindTextarea:
+		m.textarea.Focus()
+	}
+}
+
+func (m QuestionModel) Init() tea.Cmd {
+	switch m.question.GetKind() {
+	case config.QuestionKindText:
+		return textinput.Blink
+	case config.QuestionKindTextarea:
+		return textarea.Blink
+	}
+	return nil
+}
+
+func (m QuestionModel) Update(msg tea.Msg) (QuestionModel, tea.Cmd) {
+	if m.finished {
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	submitted := false
+	switch m.question.GetKind() {
+	case config.QuestionKindText:
+		submitted, m.input, cmd = handleTextInput(m.input, m.keys.Enter, msg)
+	case config.QuestionKindTextarea:
+		if msg, ok := msg.(tea.KeyMsg); ok && key.Matches(msg, m.keys.Submit) {
+			submitted = true
+			break
+		}
+		m.textarea, cmd = m.textarea.Update(msg)
+	case config.QuestionKindSelect, config.QuestionKindMultiselect:
+		m.choices, cmd = m.choices.Update(msg)
+		submitted = m.choices.IsSelected()
+	case config.QuestionKindConfirm:
+		m.confirm, cmd = m.confirm.Update(msg)
+		submitted = m.confirm.IsConfirmed()
+	}
+
+	if !submitted {
+		// 入力を変更したら前回の検証結果は消す
+		if _, ok := msg.(tea.KeyMsg); ok {
+			m.violations = nil
+		}
+		return m, cmd
+	}
+
+	m.violations = lintAnswer(m.question, m.GetValue())
+	if hasErrors(m.violations) {
+		// 選択を取り消して選び直せるようにする
+		m = m.Reset()
+		return m, cmd
+	}
+	m.finished = true
+	return m, cmd
+}
+
+func (m QuestionModel) View() string {
+	if m.finished {
+		return m.styles.Prompt.Render(m.GetPrompt()) + m.styles.Value.Render(m.valueString())
+	}
+
+	var view string
+	switch m.question.GetKind() {
+	case config.QuestionKindText:
+		view = m.input.View()
+	case config.QuestionKindTextarea:
+		view = m.textarea.View() + "\n" + m.styles.Info.Render("Press "+m.keys.Submit.Help().Key+" to continue")
+	case config.QuestionKindSelect, config.QuestionKindMultiselect:
+		view = m.choices.View()
+	case config.QuestionKindConfirm:
+		view = m.confirm.View()
+	}
+	for _, v := range m.violations {
+		view += "\n" + m.styles.violation(v)
+	}
+	return view
+}
+
+// valueString は回答済みの値を1行で表示するための文字列を返す
+func (m QuestionModel) valueString() string {
+	switch v := m.GetValue().(type) {
+	case bool:
+		if v {
+			return "Yes"
+		}
+		return "No"
+	case []string:
+		return strings.Join(v, ", ")
+	case string:
+		return strings.ReplaceAll(v, "\n", " ")
+	}
+	return ""
+}
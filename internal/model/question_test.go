@@ -0,0 +1,95 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/cffnpwr/git-cz-go/config"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestQuestionModel_Update(t *testing.T) {
+	tests := []struct {
+		name         string
+		question     config.Question
+		keyInputs    []tea.KeyMsg
+		wantValue    any
+		wantFinished bool
+	}{
+		{
+			name:     "[正常系] テキストを入力して確定",
+			question: config.Question{ID: "tested_on"},
+			keyInputs: []tea.KeyMsg{
+				{Type: tea.KeyRunes, Runes: []rune("linux")},
+				{Type: tea.KeyEnter},
+			},
+			wantValue:    "linux",
+			wantFinished: true,
+		},
+		{
+			name:         "[異常系] 必須の質問は空で確定できない",
+			question:     config.Question{ID: "tested_on", Required: true},
+			keyInputs:    []tea.KeyMsg{{Type: tea.KeyEnter}},
+			wantValue:    "",
+			wantFinished: false,
+		},
+		{
+			name:     "[正常系] 選択肢から選ぶ",
+			question: config.Question{ID: "risk", Kind: config.QuestionKindSelect, Choices: []string{"low", "high"}},
+			keyInputs: []tea.KeyMsg{
+				{Type: tea.KeyDown},
+				{Type: tea.KeyEnter},
+			},
+			wantValue:    "high",
+			wantFinished: true,
+		},
+		{
+			name:         "[正常系] 確認",
+			question:     config.Question{ID: "rollback", Kind: config.QuestionKindConfirm},
+			keyInputs:    []tea.KeyMsg{{Type: tea.KeyRunes, Runes: []rune("y")}},
+			wantValue:    true,
+			wantFinished: true,
+		},
+		{
+			name:     "[正常系] 複数選択",
+			question: config.Question{ID: "reviewers", Kind: config.QuestionKindMultiselect, Choices: []string{"alice", "bob"}},
+			keyInputs: []tea.KeyMsg{
+				{Type: tea.KeyTab},
+				{Type: tea.KeyDown},
+				{Type: tea.KeyTab},
+				{Type: tea.KeyEnter},
+			},
+			wantValue:    []string{"alice", "bob"},
+			wantFinished: true,
+		},
+		{
+			name:     "[異常系] 必須の複数選択は選ぶまで確定できない",
+			question: config.Question{ID: "reviewers", Kind: config.QuestionKindMultiselect, Choices: []string{"alice"}, Required: true},
+			keyInputs: []tea.KeyMsg{
+				{Type: tea.KeyEnter},
+			},
+			wantValue:    []string(nil),
+			wantFinished: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := NewQuestionModel(tt.question)
+			if err != nil {
+				t.Fatalf("NewQuestionModel() error = %v", err)
+			}
+			m.Focus()
+			for _, msg := range tt.keyInputs {
+				m, _ = m.Update(msg)
+			}
+
+			if diff := cmp.Diff(tt.wantValue, m.GetValue()); diff != "" {
+				t.Errorf("GetValue() mismatch (-want +got):\n%s", diff)
+			}
+			if m.IsFinished() != tt.wantFinished {
+				t.Errorf("IsFinished() = %v, want %v", m.IsFinished(), tt.wantFinished)
+			}
+		})
+	}
+}
@@ -0,0 +1,616 @@
+package model
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/cffnpwr/git-cz-go/config"
+	"github.com/cffnpwr/git-cz-go/pkg/component/choice"
+	"github.com/cffnpwr/git-cz-go/pkg/component/selector"
+	"github.com/cffnpwr/git-cz-go/pkg/component/wizard"
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// stageStep is a wizard step answering one stage of the commit data
+type stageStep interface {
+	wizard.Step
+	// prompt is shown with the answer of a stage answered before the wizard started
+	prompt() string
+	// prepare updates the step with the earlier answers before it is asked
+	prepare(m *Model) wizard.Step
+	// apply stores the current answer of the step in the commit data
+	apply(cd CommitData) CommitData
+	// load sets the answer given before the wizard started
+	load(cd CommitData) wizard.Step
+	// resize fits the step into the width and the height left for the current question
+	resize(width, height int) wizard.Step
+	// typing reports whether the keys typed are entered as text, which keeps printable help keys from being taken.
+	// Selectors are typing only once a filter has been typed.
+	typing() bool
+	// filtering reports whether a filter has been typed into the choices, which the clear filter key clears before going back
+	filtering() bool
+	help.KeyMap
+}
+
+var (
+	_ stageStep = typeStep{}
+	_ stageStep = scopeStep{}
+	_ stageStep = ticketStep{}
+	_ stageStep = subjectStep{}
+	_ stageStep = bodyStep{}
+	_ stageStep = breakingStep{}
+	_ stageStep = footerStep{}
+	_ stageStep = coAuthorsStep{}
+	_ stageStep = confirmStep{}
+	_ stageStep = questionStep{}
+)
+
+// typeItem はタイプを説明やグループ付きで一覧に表示する
+type typeItem struct {
+	tv config.TypeValue
+}
+
+var _ selector.RichItem = typeItem{}
+
+func (t typeItem) String() string      { return t.tv.String() }
+func (t typeItem) Description() string { return t.tv.Description }
+func (t typeItem) Group() string       { return t.tv.Group }
+func (t typeItem) Disabled() bool      { return t.tv.Disabled }
+
+// Title は名前が無い場合に値を表示する
+func (t typeItem) Title() string {
+	if t.tv.Name == "" {
+		return t.tv.Value
+	}
+	return t.tv.Name
+}
+
+// typeStep はタイプを選択するステップ
+type typeStep struct {
+	model  selector.Model
+	keys   KeyMap
+	types  []config.TypeValue // 一覧と同じ順序のタイプ
+	seeded bool               // ブランチ名からタイプを推測したか
+}
+
+func (s typeStep) Init() tea.Cmd    { return s.model.Init() }
+func (s typeStep) View() string     { return s.model.View() }
+func (s typeStep) IsFinished() bool { return s.model.IsSelected() }
+func (s typeStep) Value() any       { return s.model.GetCurrentItem() }
+func (s typeStep) prompt() string   { return s.model.Prompt + defaultPromptSeparator }
+func (s typeStep) typing() bool     { return s.model.IsFiltering() }
+func (s typeStep) filtering() bool  { return s.model.IsFiltering() }
+
+// ShortHelp は選択のキーを返す。終了のキーはモデル全体で表示する
+func (s typeStep) ShortHelp() []key.Binding {
+	return s.keys.withClearFilter([]key.Binding{s.keys.Selector.Up, s.keys.Selector.Down, s.keys.Selector.Select}, s.filtering())
+}
+
+func (s typeStep) FullHelp() [][]key.Binding { return [][]key.Binding{s.ShortHelp()} }
+
+func (s typeStep) Update(msg tea.Msg) (wizard.Step, tea.Cmd) {
+	var cmd tea.Cmd
+	s.model, cmd = s.model.Update(msg)
+	return s, cmd
+}
+
+func (s typeStep) Focus() wizard.Step {
+	s.model = s.model.Reset()
+	return s
+}
+
+func (s typeStep) resize(width, height int) wizard.Step {
+	s.model = s.model.SetSize(width, height)
+	return s
+}
+
+// prepare は最初に質問する際に、ブランチ名から推測したタイプにカーソルを合わせる
+// 回答済みのタイプはloadでカーソルを合わせているため推測しない
+func (s typeStep) prepare(m *Model) wizard.Step {
+	if s.seeded || m.answered[StageTypeSelect] || m.gitRepo == nil {
+		return s
+	}
+	s.seeded = true
+	branch, err := m.gitRepo.GetCurrentBranch()
+	if err != nil {
+		return s
+	}
+	if i := typeIndexFromBranch(s.types, branch); i >= 0 {
+		s.model = s.model.SetCursor(i)
+	}
+	return s
+}
+
+// typeIndexFromBranch は`fix/login`や`feat-123`のようにブランチ名の先頭にあるタイプ名からタイプの位置を返す
+func typeIndexFromBranch(types []config.TypeValue, branch string) int {
+	name, _, _ := strings.Cut(branch, "/")
+	if i := strings.IndexAny(name, "-_"); i >= 0 {
+		name = name[:i]
+	}
+	if name == "" {
+		return -1
+	}
+	return slices.IndexFunc(types, func(t config.TypeValue) bool {
+		return !t.Disabled && strings.EqualFold(t.TypeName(), name)
+	})
+}
+
+// load は戻って選び直す場合に備えて回答済みのタイプにカーソルを合わせる
+func (s typeStep) load(cd CommitData) wizard.Step {
+	if i := slices.IndexFunc(s.types, func(t config.TypeValue) bool { return t.Value == cd.Type }); i >= 0 {
+		s.model = s.model.SetCursor(i)
+	}
+	return s
+}
+
+// apply は選択前でもカーソル位置のタイプを反映する
+func (s typeStep) apply(cd CommitData) CommitData {
+	if item, ok := s.model.GetCurrentItem().(typeItem); ok {
+		cd.Type = item.tv.Value
+	}
+	return cd
+}
+
+// scopeStep はスコープを入力するステップ
+type scopeStep struct {
+	input    textinput.Model
+	keys     KeyMap
+	finished bool
+}
+
+func (s scopeStep) Init() tea.Cmd             { return textinput.Blink }
+func (s scopeStep) View() string              { return s.input.View() }
+func (s scopeStep) IsFinished() bool          { return s.finished }
+func (s scopeStep) Value() any                { return s.input.Value() }
+func (s scopeStep) prompt() string            { return s.input.Prompt }
+func (s scopeStep) typing() bool              { return true }
+func (s scopeStep) filtering() bool           { return false }
+func (s scopeStep) ShortHelp() []key.Binding  { return []key.Binding{s.keys.Enter} }
+func (s scopeStep) FullHelp() [][]key.Binding { return [][]key.Binding{s.ShortHelp()} }
+
+func (s scopeStep) Update(msg tea.Msg) (wizard.Step, tea.Cmd) {
+	if s.finished {
+		return s, nil
+	}
+	var cmd tea.Cmd
+	s.finished, s.input, cmd = handleTextInput(s.input, s.keys.Enter, msg)
+	return s, cmd
+}
+
+func (s scopeStep) Focus() wizard.Step {
+	s.finished = false
+	s.input.Focus()
+	return s
+}
+
+func (s scopeStep) resize(width, _ int) wizard.Step {
+	s.input = fitInput(s.input, width)
+	return s
+}
+
+func (s scopeStep) prepare(m *Model) wizard.Step {
+	s.input.SetValue(m.seedDefault(StageScope, s.input.Value(), m.typeValue().Defaults.Scope))
+	return s
+}
+
+func (s scopeStep) apply(cd CommitData) CommitData {
+	cd.Scope = s.input.Value()
+	return cd
+}
+
+func (s scopeStep) load(cd CommitData) wizard.Step {
+	s.input.SetValue(cd.Scope)
+	return s
+}
+
+// ticketStep はチケット番号を入力するステップ
+type ticketStep struct {
+	model TicketNumberModel
+}
+
+func (s ticketStep) Init() tea.Cmd             { return s.model.Init() }
+func (s ticketStep) View() string              { return s.model.View() }
+func (s ticketStep) IsFinished() bool          { return s.model.IsFinished() }
+func (s ticketStep) Value() any                { return s.model.GetValue() }
+func (s ticketStep) prompt() string            { return s.model.GetPrompt() }
+func (s ticketStep) typing() bool              { return true }
+func (s ticketStep) filtering() bool           { return false }
+func (s ticketStep) ShortHelp() []key.Binding  { return s.model.ShortHelp() }
+func (s ticketStep) FullHelp() [][]key.Binding { return s.model.FullHelp() }
+
+func (s ticketStep) Update(msg tea.Msg) (wizard.Step, tea.Cmd) {
+	var cmd tea.Cmd
+	s.model, cmd = s.model.Update(msg)
+	return s, cmd
+}
+
+func (s ticketStep) Focus() wizard.Step {
+	s.model = s.model.Reset()
+	s.model.Focus()
+	return s
+}
+
+func (s ticketStep) resize(width, _ int) wizard.Step {
+	s.model = s.model.SetWidth(width)
+	return s
+}
+
+func (s ticketStep) prepare(m *Model) wizard.Step {
+	ticket := strings.TrimPrefix(m.typeValue().Defaults.TicketNumber, m.config.TicketNumber.Prefix)
+	s.model.input.SetValue(m.seedDefault(StageTicketNumber, s.model.input.Value(), ticket))
+	return s
+}
+
+func (s ticketStep) apply(cd CommitData) CommitData {
+	cd.TicketNumber = s.model.GetValue()
+	return cd
+}
+
+func (s ticketStep) load(cd CommitData) wizard.Step {
+	s.model.input.SetValue(strings.TrimPrefix(cd.TicketNumber, s.model.config.Prefix))
+	return s
+}
+
+// subjectStep は件名を入力するステップ
+type subjectStep struct {
+	model SubjectModel
+}
+
+func (s subjectStep) Init() tea.Cmd             { return s.model.Init() }
+func (s subjectStep) View() string              { return s.model.View() }
+func (s subjectStep) IsFinished() bool          { return s.model.IsFinished() }
+func (s subjectStep) Value() any                { return s.model.GetValue() }
+func (s subjectStep) prompt() string            { return s.model.GetPrompt() }
+func (s subjectStep) typing() bool              { return true }
+func (s subjectStep) filtering() bool           { return false }
+func (s subjectStep) ShortHelp() []key.Binding  { return s.model.ShortHelp() }
+func (s subjectStep) FullHelp() [][]key.Binding { return s.model.FullHelp() }
+
+func (s subjectStep) Update(msg tea.Msg) (wizard.Step, tea.Cmd) {
+	var cmd tea.Cmd
+	s.model, cmd = s.model.Update(msg)
+	return s, cmd
+}
+
+func (s subjectStep) Focus() wizard.Step {
+	s.model = s.model.Reset()
+	s.model.Focus()
+	return s
+}
+
+func (s subjectStep) resize(width, _ int) wizard.Step {
+	s.model = s.model.SetWidth(width)
+	return s
+}
+
+func (s subjectStep) prepare(m *Model) wizard.Step {
+	s.model = s.model.SetCommitData(m.commitData)
+	return s
+}
+
+func (s subjectStep) apply(cd CommitData) CommitData {
+	cd.Subject = s.model.GetValue()
+	return cd
+}
+
+func (s subjectStep) load(cd CommitData) wizard.Step {
+	s.model = s.model.SetValue(cd.Subject)
+	return s
+}
+
+// bodyStep は本文を入力するステップ
+type bodyStep struct {
+	model BodyModel
+}
+
+func (s bodyStep) Init() tea.Cmd             { return s.model.Init() }
+func (s bodyStep) View() string              { return s.model.View() }
+func (s bodyStep) IsFinished() bool          { return s.model.IsFinished() }
+func (s bodyStep) Value() any                { return s.model.GetValue() }
+func (s bodyStep) prompt() string            { return s.model.GetPrompt() }
+func (s bodyStep) typing() bool              { return true }
+func (s bodyStep) filtering() bool           { return false }
+func (s bodyStep) ShortHelp() []key.Binding  { return s.model.ShortHelp() }
+func (s bodyStep) FullHelp() [][]key.Binding { return s.model.FullHelp() }
+
+func (s bodyStep) Update(msg tea.Msg) (wizard.Step, tea.Cmd) {
+	var cmd tea.Cmd
+	s.model, cmd = s.model.Update(msg)
+	return s, cmd
+}
+
+func (s bodyStep) Focus() wizard.Step {
+	s.model = s.model.Reset()
+	s.model.Focus()
+	return s
+}
+
+func (s bodyStep) resize(width, height int) wizard.Step {
+	s.model = s.model.SetSize(width, height)
+	return s
+}
+
+func (s bodyStep) prepare(m *Model) wizard.Step {
+	s.model = s.model.SetType(m.typeValue())
+	return s
+}
+
+func (s bodyStep) apply(cd CommitData) CommitData {
+	cd.Body = s.model.GetValue()
+	return cd
+}
+
+func (s bodyStep) load(cd CommitData) wizard.Step {
+	s.model = s.model.SetValue(cd.Body)
+	return s
+}
+
+// breakingStep は破壊的変更の有無と内容を入力するステップ
+type breakingStep struct {
+	model BreakingChangesModel
+}
+
+func (s breakingStep) Init() tea.Cmd             { return s.model.Init() }
+func (s breakingStep) View() string              { return s.model.View() }
+func (s breakingStep) IsFinished() bool          { return s.model.IsFinished() }
+func (s breakingStep) Value() any                { return s.model.GetValue() }
+func (s breakingStep) prompt() string            { return s.model.GetMessagePrompt() + defaultPromptSeparator }
+func (s breakingStep) typing() bool              { return s.model.stage == BreakingStageInput }
+func (s breakingStep) filtering() bool           { return false }
+func (s breakingStep) ShortHelp() []key.Binding  { return s.model.ShortHelp() }
+func (s breakingStep) FullHelp() [][]key.Binding { return s.model.FullHelp() }
+
+func (s breakingStep) Update(msg tea.Msg) (wizard.Step, tea.Cmd) {
+	var cmd tea.Cmd
+	s.model, cmd = s.model.Update(msg)
+	return s, cmd
+}
+
+func (s breakingStep) Focus() wizard.Step {
+	s.model = s.model.Reset()
+	s.model.Focus()
+	return s
+}
+
+func (s breakingStep) resize(width, _ int) wizard.Step {
+	s.model = s.model.SetWidth(width)
+	return s
+}
+
+func (s breakingStep) prepare(*Model) wizard.Step { return s }
+func (s breakingStep) load(cd CommitData) wizard.Step {
+	s.model = s.model.SetValue(cd.IsBreaking, cd.BreakingChanges)
+	return s
+}
+
+func (s breakingStep) apply(cd CommitData) CommitData {
+	cd.BreakingChanges = s.model.GetValue()
+	cd.IsBreaking = s.model.HasBreakingChanges()
+	return cd
+}
+
+// footerStep はフッターを入力するステップ
+type footerStep struct {
+	model FooterModel
+}
+
+func (s footerStep) Init() tea.Cmd             { return s.model.Init() }
+func (s footerStep) View() string              { return s.model.View() }
+func (s footerStep) IsFinished() bool          { return s.model.IsFinished() }
+func (s footerStep) Value() any                { return s.model.GetValue() }
+func (s footerStep) prompt() string            { return s.model.GetPrompt() + defaultPromptSeparator }
+func (s footerStep) typing() bool              { return true }
+func (s footerStep) filtering() bool           { return false }
+func (s footerStep) ShortHelp() []key.Binding  { return s.model.ShortHelp() }
+func (s footerStep) FullHelp() [][]key.Binding { return s.model.FullHelp() }
+
+func (s footerStep) Update(msg tea.Msg) (wizard.Step, tea.Cmd) {
+	var cmd tea.Cmd
+	s.model, cmd = s.model.Update(msg)
+	return s, cmd
+}
+
+func (s footerStep) Focus() wizard.Step {
+	s.model = s.model.Reset()
+	s.model.Focus()
+	return s
+}
+
+func (s footerStep) resize(width, height int) wizard.Step {
+	s.model = s.model.SetSize(width, height)
+	return s
+}
+
+func (s footerStep) prepare(m *Model) wizard.Step {
+	s.model.textarea.SetValue(m.seedDefault(StageFooter, s.model.textarea.Value(), m.typeValue().Defaults.Footer))
+	return s
+}
+
+func (s footerStep) apply(cd CommitData) CommitData {
+	cd.Footer = s.model.GetValue()
+	return cd
+}
+
+func (s footerStep) load(cd CommitData) wizard.Step {
+	s.model.textarea.SetValue(cd.Footer)
+	return s
+}
+
+// coAuthorsStep は共同作成者を選択するステップ
+type coAuthorsStep struct {
+	model      selector.Model
+	keys       KeyMap
+	candidates []string
+}
+
+func (s coAuthorsStep) Init() tea.Cmd             { return s.model.Init() }
+func (s coAuthorsStep) View() string              { return s.model.View() }
+func (s coAuthorsStep) IsFinished() bool          { return s.model.IsSelected() }
+func (s coAuthorsStep) Value() any                { return checkedValues(s.model) }
+func (s coAuthorsStep) prompt() string            { return s.model.Prompt + defaultPromptSeparator }
+func (s coAuthorsStep) typing() bool              { return s.model.IsFiltering() }
+func (s coAuthorsStep) filtering() bool           { return s.model.IsFiltering() }
+func (s coAuthorsStep) ShortHelp() []key.Binding  { return s.keys.multiSelectShortHelp(s.filtering()) }
+func (s coAuthorsStep) FullHelp() [][]key.Binding { return s.keys.multiSelectFullHelp() }
+
+func (s coAuthorsStep) Update(msg tea.Msg) (wizard.Step, tea.Cmd) {
+	var cmd tea.Cmd
+	s.model, cmd = s.model.Update(msg)
+	return s, cmd
+}
+
+func (s coAuthorsStep) Focus() wizard.Step {
+	s.model = s.model.Reset()
+	return s
+}
+
+func (s coAuthorsStep) resize(width, height int) wizard.Step {
+	s.model = s.model.SetSize(width, height)
+	return s
+}
+
+func (s coAuthorsStep) prepare(*Model) wizard.Step { return s }
+
+// load は回答済みの共同作成者をチェックする。候補に無い共同作成者は候補に加える
+func (s coAuthorsStep) load(cd CommitData) wizard.Step {
+	choices := slices.Clone(s.candidates)
+	for _, c := range cd.CoAuthors {
+		if !slices.Contains(choices, c) {
+			choices = append(choices, c)
+		}
+	}
+	s.model = checkValues(s.model.SetItems(choiceItems(choices)), choices, cd.CoAuthors)
+	return s
+}
+
+// setCandidates は候補を差し替え、チェック済みの共同作成者は残す
+func (s coAuthorsStep) setCandidates(candidates []string) wizard.Step {
+	s.candidates = candidates
+	return s.load(CommitData{CoAuthors: checkedValues(s.model)})
+}
+
+func (s coAuthorsStep) apply(cd CommitData) CommitData {
+	cd.CoAuthors = checkedValues(s.model)
+	return cd
+}
+
+// confirmStep はコミットするかを確認するステップ
+// 回答はコミットデータではなくModelがコミットの可否として扱う
+type confirmStep struct {
+	model choice.Model
+	keys  KeyMap
+}
+
+// confirmAction は確認画面の選択肢。並びは選択肢の表示順と同じ
+type confirmAction int
+
+const (
+	confirmYes confirmAction = iota
+	confirmEdit
+	confirmBack
+	confirmCancel
+)
+
+// newConfirmChoice は確認画面の選択肢を作る。誤ってコミットしないよう取り消しを既定とする
+func newConfirmChoice(labels config.ConfirmOptions, km KeyMap) (choice.Model, error) {
+	label := func(s, def string) string {
+		if s != "" {
+			return s
+		}
+		return def
+	}
+	c, err := choice.New(
+		choice.Option{Label: label(labels.Yes, defaultConfirmYesLabel), Key: km.Yes},
+		choice.Option{Label: label(labels.Edit, defaultConfirmEditLabel), Key: km.Edit},
+		choice.Option{Label: label(labels.Back, defaultConfirmBackLabel), Key: km.Back},
+		choice.Option{Label: label(labels.Cancel, defaultConfirmCancelLabel), Key: km.No},
+	)
+	if err != nil {
+		return choice.Model{}, err
+	}
+	return c.SetKeyMap(km.Choice).SetDefault(int(confirmCancel)), nil
+}
+
+func (s confirmStep) Init() tea.Cmd    { return s.model.Init() }
+func (s confirmStep) View() string     { return s.model.View() }
+func (s confirmStep) IsFinished() bool { return s.model.IsConfirmed() }
+func (s confirmStep) Value() any       { return confirmAction(s.model.GetIndex()) }
+func (s confirmStep) prompt() string   { return s.model.Prompt }
+func (s confirmStep) typing() bool     { return false }
+func (s confirmStep) filtering() bool  { return false }
+
+// ShortHelp は回答のキーを返す。選択肢の移動は全体のヘルプに、前の質問へ戻るキーはモデル全体で表示する
+func (s confirmStep) ShortHelp() []key.Binding {
+	km := s.keys
+	return []key.Binding{km.Yes, km.Edit, km.No, km.Choice.Select}
+}
+
+func (s confirmStep) FullHelp() [][]key.Binding {
+	km := s.keys
+	return [][]key.Binding{{km.Choice.Prev, km.Choice.Next, km.Choice.Select}, {km.Yes, km.Edit, km.No}}
+}
+
+func (s confirmStep) Update(msg tea.Msg) (wizard.Step, tea.Cmd) {
+	var cmd tea.Cmd
+	s.model, cmd = s.model.Update(msg)
+	return s, cmd
+}
+
+func (s confirmStep) Focus() wizard.Step {
+	s.model = s.model.Reset()
+	return s
+}
+
+func (s confirmStep) resize(width, _ int) wizard.Step {
+	s.model = s.model.SetWidth(width)
+	return s
+}
+
+func (s confirmStep) prepare(*Model) wizard.Step     { return s }
+func (s confirmStep) load(CommitData) wizard.Step    { return s }
+func (s confirmStep) apply(cd CommitData) CommitData { return cd }
+
+// questionStep はカスタム質問のステップ
+type questionStep struct {
+	model QuestionModel
+}
+
+func (s questionStep) Init() tea.Cmd             { return s.model.Init() }
+func (s questionStep) View() string              { return s.model.View() }
+func (s questionStep) IsFinished() bool          { return s.model.IsFinished() }
+func (s questionStep) Value() any                { return s.model.GetValue() }
+func (s questionStep) prompt() string            { return s.model.GetPrompt() }
+func (s questionStep) typing() bool              { return s.model.typing() }
+func (s questionStep) filtering() bool           { return s.model.choices.IsFiltering() }
+func (s questionStep) ShortHelp() []key.Binding  { return s.model.ShortHelp() }
+func (s questionStep) FullHelp() [][]key.Binding { return s.model.FullHelp() }
+
+func (s questionStep) Update(msg tea.Msg) (wizard.Step, tea.Cmd) {
+	var cmd tea.Cmd
+	s.model, cmd = s.model.Update(msg)
+	return s, cmd
+}
+
+func (s questionStep) Focus() wizard.Step {
+	s.model = s.model.Reset()
+	s.model.Focus()
+	return s
+}
+
+func (s questionStep) resize(width, height int) wizard.Step {
+	s.model = s.model.SetSize(width, height)
+	return s
+}
+
+func (s questionStep) prepare(*Model) wizard.Step { return s }
+func (s questionStep) load(cd CommitData) wizard.Step {
+	s.model = s.model.SetValue(cd.Custom[s.model.question.ID])
+	return s
+}
+
+func (s questionStep) apply(cd CommitData) CommitData {
+	return cd.setCustom(s.model.question.ID, s.model.GetValue())
+}
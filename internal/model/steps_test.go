@@ -0,0 +1,437 @@
+package model
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/cffnpwr/git-cz-go/config"
+	"github.com/cffnpwr/git-cz-go/internal/mock/repo"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/google/go-cmp/cmp"
+	"go.uber.org/mock/gomock"
+)
+
+// createTestConfirmModel は全ての質問に回答済みで確認画面から始まるモデルを作る
+func createTestConfirmModel(t *testing.T, cfg *config.Config) Model {
+	t.Helper()
+	m := createTestPreviewModel(t, cfg)
+	answered := make([]Stage, 0, len(stageOrder))
+	for _, s := range stageOrder {
+		if s != StageConfirm {
+			answered = append(answered, s)
+		}
+	}
+	m = m.SetPrefill(Prefill{
+		Data:     CommitData{Type: "fix: :bug:", TicketNumber: "#1", Subject: "fix it"},
+		Answered: answered,
+	}).SetDryRun(true)
+	if m.currentStage() != StageConfirm {
+		t.Fatalf("currentStage() = %q, want %q", m.currentStage(), StageConfirm)
+	}
+	return m
+}
+
+func TestModel_ConfirmAction(t *testing.T) {
+	tests := []struct {
+		name        string
+		keyInputs   []tea.KeyMsg
+		wantOutcome OutcomeKind
+		wantQuit    bool
+		wantStage   Stage
+	}{
+		{
+			name:        "[正常系] yでコミット",
+			keyInputs:   []tea.KeyMsg{{Type: tea.KeyRunes, Runes: []rune("y")}},
+			wantOutcome: OutcomeConfirmed,
+			wantQuit:    true,
+			wantStage:   StageConfirm,
+		},
+		{
+			name:        "[正常系] 既定の取り消しを確定",
+			keyInputs:   []tea.KeyMsg{{Type: tea.KeyEnter}},
+			wantOutcome: OutcomeCancelled,
+			wantQuit:    true,
+			wantStage:   StageConfirm,
+		},
+		{
+			name:        "[正常系] 移動してYesを確定",
+			keyInputs:   []tea.KeyMsg{{Type: tea.KeyTab}, {Type: tea.KeyEnter}},
+			wantOutcome: OutcomeConfirmed,
+			wantQuit:    true,
+			wantStage:   StageConfirm,
+		},
+		{
+			// 戻る先が無い場合は確認画面に留まり選び直せる
+			name:      "[正常系] 戻る先が無い場合のBack",
+			keyInputs: []tea.KeyMsg{{Type: tea.KeyRunes, Runes: []rune("l")}, {Type: tea.KeyRunes, Runes: []rune("l")}, {Type: tea.KeyRunes, Runes: []rune("l")}, {Type: tea.KeyEnter}},
+			wantStage: StageConfirm,
+		},
+		{
+			// Escは戻るキーのため、戻る先が無くても終了しない
+			name:      "[正常系] 戻る先が無い場合のEsc",
+			keyInputs: []tea.KeyMsg{{Type: tea.KeyEsc}},
+			wantStage: StageConfirm,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := createTestConfirmModel(t, createTestConfig())
+			var cmd tea.Cmd
+			for _, msg := range tt.keyInputs {
+				var updated tea.Model
+				updated, cmd = m.Update(msg)
+				m = updated.(Model)
+			}
+
+			if got := m.currentStage(); got != tt.wantStage {
+				t.Errorf("currentStage() = %q, want %q", got, tt.wantStage)
+			}
+			if (cmd != nil) != tt.wantQuit {
+				t.Errorf("cmd = %v, want quit %t", cmd, tt.wantQuit)
+			}
+			if tt.wantQuit && m.GetOutcome().Kind != tt.wantOutcome {
+				t.Errorf("GetOutcome() = %v, want %v", m.GetOutcome().Kind, tt.wantOutcome)
+			}
+			if !tt.wantQuit && m.wizard.CurrentStep().IsFinished() {
+				t.Error("expected the confirm stage to be answerable again")
+			}
+		})
+	}
+}
+
+func TestNewConfirmChoice(t *testing.T) {
+	cfg := createTestConfig()
+	cfg.Messages.ConfirmOptions = config.ConfirmOptions{Yes: "はい", Cancel: "やめる"}
+	m := createTestConfirmModel(t, cfg)
+
+	view := m.View()
+	for _, want := range []string{"はい", "Edit", "Back", "やめる"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("View() = %q, want to contain %q", view, want)
+		}
+	}
+}
+
+func TestModel_JumpBackToAnswered(t *testing.T) {
+	data := CommitData{
+		Type:            "feat: :sparkles:",
+		TicketNumber:    "#1",
+		Subject:         "add it",
+		BreakingChanges: "drop v1",
+		IsBreaking:      true,
+		// 候補に無い共同作成者も残る
+		CoAuthors: []string{"Alice <alice@example.com>", "Bob <bob@example.com>"},
+		Custom: map[string]any{
+			"tested_on": "linux",
+			"risk":      "high",
+			"migrate":   true,
+			"areas":     []string{"ui", "db"},
+		},
+	}
+	enter := tea.KeyMsg{Type: tea.KeyEnter}
+
+	tests := []struct {
+		name      string
+		stage     Stage
+		keyInputs []tea.KeyMsg
+	}{
+		{
+			name:      "[正常系] 破壊的変更の有無と内容",
+			stage:     StageBreaking,
+			keyInputs: []tea.KeyMsg{enter, {Type: tea.KeyEnter, Alt: true}},
+		},
+		{
+			name:      "[正常系] チェックした共同作成者",
+			stage:     StageCoAuthors,
+			keyInputs: []tea.KeyMsg{enter},
+		},
+		{
+			name:      "[正常系] テキストのカスタム質問",
+			stage:     "tested_on",
+			keyInputs: []tea.KeyMsg{enter},
+		},
+		{
+			name:      "[正常系] 選択のカスタム質問",
+			stage:     "risk",
+			keyInputs: []tea.KeyMsg{enter},
+		},
+		{
+			name:      "[正常系] 確認のカスタム質問",
+			stage:     "migrate",
+			keyInputs: []tea.KeyMsg{enter},
+		},
+		{
+			name:      "[正常系] 複数選択のカスタム質問",
+			stage:     "areas",
+			keyInputs: []tea.KeyMsg{enter},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := repo.NewMockGitRepository(ctrl)
+			mockRepo.EXPECT().GetRecentAuthors(gomock.Any()).Return(nil, errors.New("no commits")).AnyTimes()
+
+			cfg := createTestConfig()
+			cfg.SkipQuestions = config.SkipQuestions{"scope", "body", "footer"}
+			cfg.CoAuthors = []config.CoAuthor{{Name: "Alice", Email: "alice@example.com"}}
+			cfg.Questions = []config.Question{
+				{ID: "tested_on"},
+				{ID: "risk", Kind: config.QuestionKindSelect, Choices: []string{"low", "high"}},
+				{ID: "migrate", Kind: config.QuestionKindConfirm},
+				{ID: "areas", Kind: config.QuestionKindMultiselect, Choices: []string{"api", "ui", "db"}},
+			}
+			m, err := NewModel(cfg, mockRepo, lipgloss.DefaultRenderer())
+			if err != nil {
+				t.Fatalf("NewModel() error = %v", err)
+			}
+			var answered []Stage
+			for _, id := range m.wizard.IDs() {
+				if Stage(id) != StageConfirm && !m.isStageSkipped(Stage(id)) {
+					answered = append(answered, Stage(id))
+				}
+			}
+			m = m.SetPrefill(Prefill{Data: data, Answered: answered}).SetDryRun(true)
+
+			// 確認画面で番号を押して戻り、そのまま回答し直す
+			n := slices.Index(m.progressStages(), tt.stage) + 1
+			updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(strconv.Itoa(n))})
+			m = updated.(Model)
+			if got := m.currentStage(); got != tt.stage {
+				t.Fatalf("currentStage() = %q, want %q", got, tt.stage)
+			}
+			for _, msg := range tt.keyInputs {
+				updated, _ = m.Update(msg)
+				m = updated.(Model)
+			}
+
+			if got := m.currentStage(); got != StageConfirm {
+				t.Errorf("currentStage() = %q, want %q", got, StageConfirm)
+			}
+			if diff := cmp.Diff(data, m.GetCommitData()); diff != "" {
+				t.Errorf("GetCommitData() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestModel_JumpBackAfterEditing(t *testing.T) {
+	m := createTestConfirmModel(t, createTestConfig())
+
+	// エディタで件名と本文を書き換える
+	path := filepath.Join(t.TempDir(), "COMMIT_EDITMSG")
+	message := strings.Replace(m.GetCommitMessage(), "fix it", "fix the parser", 1) + "\n\nkeep the quotes"
+	if err := os.WriteFile(path, []byte(message), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	m = m.handleEditorFinished(editorFinishedMsg{path: path})
+	want := m.GetCommitData()
+	if want.Subject != "fix the parser" || want.Body != "keep the quotes" {
+		t.Fatalf("GetCommitData() = %+v, want the edited subject and body", want)
+	}
+
+	// 戻って回答し直しても編集した値が残る
+	for _, stage := range []Stage{StageSubject, StageBody} {
+		n := slices.Index(m.progressStages(), stage) + 1
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(strconv.Itoa(n))})
+		m = updated.(Model)
+		if got := m.currentStage(); got != stage {
+			t.Fatalf("currentStage() = %q, want %q", got, stage)
+		}
+		updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter, Alt: stage == StageBody})
+		m = updated.(Model)
+		if got := m.currentStage(); got != StageConfirm {
+			t.Fatalf("currentStage() = %q, want %q", got, StageConfirm)
+		}
+	}
+	if diff := cmp.Diff(want, m.GetCommitData()); diff != "" {
+		t.Errorf("GetCommitData() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestTypeIndexFromBranch(t *testing.T) {
+	types := []config.TypeValue{
+		{Value: "feat: :sparkles:", Name: "feat"},
+		{Value: "fix: :bug:", Name: "fix"},
+		{Value: "docs: :memo:", Name: "docs", Disabled: true},
+	}
+
+	tests := []struct {
+		name   string
+		branch string
+		want   int
+	}{
+		{name: "[正常系] スラッシュで区切られたタイプ名", branch: "fix/login-form", want: 1},
+		{name: "[正常系] ハイフンで区切られたタイプ名", branch: "feat-123-login", want: 0},
+		{name: "[正常系] 大文字のタイプ名", branch: "FIX/login", want: 1},
+		{name: "[異常系] 無効なタイプ", branch: "docs/readme", want: -1},
+		{name: "[異常系] タイプ名で始まらない", branch: "main", want: -1},
+		{name: "[異常系] 前方一致のみ", branch: "fixup/login", want: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := typeIndexFromBranch(types, tt.branch); got != tt.want {
+				t.Errorf("typeIndexFromBranch() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestModel_TypeStage(t *testing.T) {
+	tests := []struct {
+		name      string
+		branch    string
+		prefill   Prefill
+		enter     Stage
+		keyInputs []tea.KeyMsg
+		wantType  string
+		wantStage Stage
+	}{
+		{
+			name:      "[正常系] ブランチ名のタイプにカーソルを合わせる",
+			branch:    "fix/login",
+			wantType:  "fix: :bug:",
+			wantStage: StageTypeSelect,
+		},
+		{
+			name:      "[正常系] 推測できない場合は先頭",
+			branch:    "main",
+			wantType:  "feat: :sparkles:",
+			wantStage: StageTypeSelect,
+		},
+		{
+			name:      "[正常系] 数字で表示順のタイプを選択",
+			branch:    "main",
+			keyInputs: []tea.KeyMsg{{Type: tea.KeyRunes, Runes: []rune("3")}},
+			wantType:  "docs: :memo:",
+			wantStage: StageScope,
+		},
+		{
+			name:      "[正常系] 回答済みのタイプはブランチ名より優先",
+			prefill:   Prefill{Data: CommitData{Type: "docs: :memo:"}, Answered: []Stage{StageTypeSelect}},
+			enter:     StageTypeSelect,
+			wantType:  "docs: :memo:",
+			wantStage: StageTypeSelect,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := repo.NewMockGitRepository(ctrl)
+			if tt.branch != "" {
+				mockRepo.EXPECT().GetCurrentBranch().Return(tt.branch, nil)
+			}
+
+			cfg := createTestConfig()
+			cfg.SkipQuestions = config.SkipQuestions{"co_authors"}
+			m, err := NewModel(cfg, mockRepo, lipgloss.DefaultRenderer())
+			if err != nil {
+				t.Fatalf("NewModel() error = %v", err)
+			}
+			m = m.SetPrefill(tt.prefill)
+			if tt.enter != "" {
+				m.enterStage(tt.enter)
+			}
+			for _, msg := range tt.keyInputs {
+				updated, _ := m.Update(msg)
+				m = updated.(Model)
+			}
+
+			if got := m.currentStage(); got != tt.wantStage {
+				t.Errorf("currentStage() = %q, want %q", got, tt.wantStage)
+			}
+			if got := m.previewData().Type; got != tt.wantType {
+				t.Errorf("Type = %q, want %q", got, tt.wantType)
+			}
+		})
+	}
+}
+
+func TestModel_RecentAuthors(t *testing.T) {
+	authors := []object.Signature{
+		{Name: "Alice", Email: "alice@example.com"},
+		{Name: "Dave", Email: "DAVE@example.com"},
+	}
+
+	tests := []struct {
+		name           string
+		authors        []object.Signature
+		err            error
+		checked        []string
+		wantCandidates []string
+		wantChecked    []string
+	}{
+		{
+			name:           "[正常系] 履歴の作成者を設定の候補より前に並べる",
+			authors:        authors,
+			wantCandidates: []string{"Alice <alice@example.com>", "Dave <DAVE@example.com>", "Carol <carol@example.com>"},
+		},
+		{
+			name:           "[正常系] 読み込み前にチェックした共同作成者は残す",
+			authors:        authors,
+			checked:        []string{"Carol <carol@example.com>"},
+			wantCandidates: []string{"Alice <alice@example.com>", "Dave <DAVE@example.com>", "Carol <carol@example.com>"},
+			wantChecked:    []string{"Carol <carol@example.com>"},
+		},
+		{
+			name:           "[異常系] 履歴が取得できない場合は設定の候補のみ",
+			err:            errors.New("no commits"),
+			wantCandidates: []string{"Dave <dave@example.com>", "Carol <carol@example.com>"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := repo.NewMockGitRepository(ctrl)
+			mockRepo.EXPECT().GetCurrentBranch().Return("main", nil).AnyTimes()
+			mockRepo.EXPECT().GetRecentAuthors(defaultCoAuthorLogLimit).Return(tt.authors, tt.err)
+
+			cfg := createTestConfig()
+			cfg.CoAuthors = []config.CoAuthor{
+				{Name: "Dave", Email: "dave@example.com"},
+				{Name: "Carol", Email: "carol@example.com"},
+			}
+			m, err := NewModel(cfg, mockRepo, lipgloss.DefaultRenderer())
+			if err != nil {
+				t.Fatalf("NewModel() error = %v", err)
+			}
+			if tt.checked != nil {
+				m = m.SetPrefill(Prefill{Data: CommitData{CoAuthors: tt.checked}, Answered: []Stage{StageCoAuthors}})
+			}
+
+			// 履歴はモデルの作成時ではなくコマンドで読み込む
+			cmd := m.loadRecentAuthors()
+			if cmd == nil {
+				t.Fatal("loadRecentAuthors() = nil, want a command")
+			}
+			updated, _ := m.Update(cmd())
+			m = updated.(Model)
+
+			step := m.wizard.Step(string(StageCoAuthors)).(coAuthorsStep)
+			if diff := cmp.Diff(tt.wantCandidates, step.candidates); diff != "" {
+				t.Errorf("candidates mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantChecked, checkedValues(step.model)); diff != "" {
+				t.Errorf("checked mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package model
+
+import (
+	"github.com/cffnpwr/git-cz-go/config"
+	"github.com/cffnpwr/git-cz-go/pkg/theme"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// SubjectModel は件名を入力し、ヘッダーのルールに従って入力中に検証するモデル
+type SubjectModel struct {
+	input      textinput.Model
+	config     config.Header
+	data       CommitData // ヘッダーの長さの検証に使用する入力済みの他の項目
+	keys       KeyMap
+	styles     Styles
+	finished   bool
+	violations []Violation
+}
+
+func NewSubjectModel(prompt string, hCfg config.Header) SubjectModel {
+	input := textinput.New()
+	if prompt == "" {
+		prompt = defaultSubjectPrompt
+	}
+	input.Prompt = prompt + defaultPromptSeparator
+	input.Focus()
+
+	return SubjectModel{
+		input:  input,
+		config: hCfg,
+		keys:   DefaultKeyMap,
+	}.SetStyles(NewStyles(lipgloss.DefaultRenderer(), theme.Default))
+}
+
+// SetKeyMap sets the keys used to finish the input
+func (m SubjectModel) SetKeyMap(km KeyMap) SubjectModel {
+	m.keys = km
+	return m
+}
+
+// SetStyles sets the styles of the prompt and the messages under the input
+func (m SubjectModel) SetStyles(s Styles) SubjectModel {
+	m.styles = s
+	m.input.PromptStyle = s.Prompt
+	return m
+}
+
+// SetWidth fits the input into the width
+func (m SubjectModel) SetWidth(w int) SubjectModel {
+	m.input = fitInput(m.input, w)
+	return m
+}
+
+// ShortHelp returns the keys shown in the help line, implementing help.KeyMap
+func (m SubjectModel) ShortHelp() []key.Binding {
+	return []key.Binding{m.keys.Enter}
+}
+
+// FullHelp returns the keys shown in the full help, implementing help.KeyMap
+func (m SubjectModel) FullHelp() [][]key.Binding {
+	return [][]key.Binding{m.ShortHelp()}
+}
+
+func (m SubjectModel) GetPrompt() string {
+	return m.input.Prompt
+}
+
+func (m SubjectModel) GetValue() string {
+	return m.input.Value()
+}
+
+func (m SubjectModel) SetValue(s string) SubjectModel {
+	m.input.SetValue(s)
+	return m
+}
+
+// SetCommitData sets the answers of the other stages used to validate the whole header
+func (m SubjectModel) SetCommitData(cd CommitData) SubjectModel {
+	m.data = cd
+	m.violations = m.validateInput()
+	return m
+}
+
+func (m SubjectModel) IsFinished() bool {
+	return m.finished
+}
+
+// Reset makes the model editable again while keeping the entered value
+func (m SubjectModel) Reset() SubjectModel {
+	m.finished = false
+	return m
+}
+
+func (m *SubjectModel) Focus() {
+	m.input.Focus()
+}
+
+func (m SubjectModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m SubjectModel) Update(msg tea.Msg) (SubjectModel, tea.Cmd) {
+	if m.finished {
+		return m, nil
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if key.Matches(msg, m.keys.Enter) {
+			m.violations = m.validateInput()
+			if !hasErrors(m.violations) {
+				m.finished = true
+			}
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	m.violations = m.validateInput()
+	return m, cmd
+}
+
+func (m SubjectModel) View() string {
+	view := m.input.View()
+	if m.finished {
+		return view
+	}
+
+	for _, v := range m.violations {
+		view += "\n" + m.styles.violation(v)
+	}
+	if !hasErrors(m.violations) && m.input.Value() != "" {
+		view += "\n" + m.styles.Info.Render("Press Enter to continue")
+	}
+
+	return view
+}
+
+func (m SubjectModel) validateInput() []Violation {
+	cd := m.data
+	cd.Subject = m.input.Value()
+	return lintHeader(m.config, cd)
+}
@@ -0,0 +1,69 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/cffnpwr/git-cz-go/config"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestSubjectModel_Update(t *testing.T) {
+	tests := []struct {
+		name         string
+		hCfg         config.Header
+		keyInputs    []tea.KeyMsg
+		wantValue    string
+		wantFinished bool
+	}{
+		{
+			name: "[正常系] 入力して確定",
+			keyInputs: []tea.KeyMsg{
+				{Type: tea.KeyRunes, Runes: []rune("add form")},
+				{Type: tea.KeyEnter},
+			},
+			wantValue:    "add form",
+			wantFinished: true,
+		},
+		{
+			name:         "[異常系] 空の件名では確定できない",
+			keyInputs:    []tea.KeyMsg{{Type: tea.KeyEnter}},
+			wantFinished: false,
+		},
+		{
+			name: "[異常系] エラーのルールに違反すると確定できない",
+			hCfg: config.Header{NoTrailingPeriod: &config.Rule[bool]{Value: true}},
+			keyInputs: []tea.KeyMsg{
+				{Type: tea.KeyRunes, Runes: []rune("add form.")},
+				{Type: tea.KeyEnter},
+			},
+			wantValue:    "add form.",
+			wantFinished: false,
+		},
+		{
+			name: "[正常系] 警告のルールに違反しても確定できる",
+			hCfg: config.Header{NoTrailingPeriod: &config.Rule[bool]{Value: true, Level: config.RuleLevelWarning}},
+			keyInputs: []tea.KeyMsg{
+				{Type: tea.KeyRunes, Runes: []rune("add form.")},
+				{Type: tea.KeyEnter},
+			},
+			wantValue:    "add form.",
+			wantFinished: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := NewSubjectModel("", tt.hCfg).SetCommitData(CommitData{Type: "feat"})
+			for _, k := range tt.keyInputs {
+				model, _ = model.Update(k)
+			}
+
+			if model.GetValue() != tt.wantValue {
+				t.Errorf("GetValue() = %q, want %q", model.GetValue(), tt.wantValue)
+			}
+			if model.IsFinished() != tt.wantFinished {
+				t.Errorf("IsFinished() = %v, want %v", model.IsFinished(), tt.wantFinished)
+			}
+		})
+	}
+}
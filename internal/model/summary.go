@@ -0,0 +1,80 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cffnpwr/git-cz-go/internal/interface/repo"
+)
+
+const (
+	shortHashLength = 7
+)
+
+// commitSummary は作成したコミットの概要
+type commitSummary struct {
+	hash     string
+	branch   string
+	header   string
+	stats    repo.CommitStats
+	hasStats bool
+	styles   Styles
+}
+
+// newCommitSummary はコミット後にブランチ名と変更量を取得して概要を作る
+// 取得に失敗した項目は表示しない
+func newCommitSummary(gitRepo repo.GitRepository, hash, message string, styles Styles) commitSummary {
+	s := commitSummary{
+		hash:   hash,
+		header: strings.SplitN(message, "\n", 2)[0],
+		styles: styles,
+	}
+
+	if branch, err := gitRepo.GetCurrentBranch(); err == nil {
+		s.branch = branch
+	}
+	if stats, err := gitRepo.GetCommitStats(hash); err == nil {
+		s.stats = stats
+		s.hasStats = true
+	}
+	return s
+}
+
+func (s commitSummary) View() string {
+	hash := s.hash
+	if len(hash) > shortHashLength {
+		hash = hash[:shortHashLength]
+	}
+
+	title := "Committed " + s.styles.Hash.Render(hash)
+	if s.branch != "" {
+		title += " on " + s.styles.Prompt.Render(s.branch)
+	}
+
+	lines := []string{s.header}
+	if s.hasStats {
+		lines = append(lines, formatStats(s.stats))
+	}
+	lines = append(lines, s.styles.Info.Render("Next: run `git push` to share it, or `git commit --amend` to fix it"))
+
+	return s.styles.Icon.Render(defaultIconCharEntered) + title + "\n" + s.styles.SummaryBody.Render(strings.Join(lines, "\n"))
+}
+
+// formatStats は`git commit`と同じ形式で変更量を表示する
+func formatStats(stats repo.CommitStats) string {
+	plural := func(n int, word string) string {
+		if n == 1 {
+			return fmt.Sprintf("%d %s", n, word)
+		}
+		return fmt.Sprintf("%d %ss", n, word)
+	}
+
+	parts := []string{plural(stats.FilesChanged, "file") + " changed"}
+	if stats.Insertions > 0 || stats.Deletions == 0 {
+		parts = append(parts, plural(stats.Insertions, "insertion")+"(+)")
+	}
+	if stats.Deletions > 0 {
+		parts = append(parts, plural(stats.Deletions, "deletion")+"(-)")
+	}
+	return strings.Join(parts, ", ")
+}
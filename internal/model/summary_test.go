@@ -0,0 +1,139 @@
+package model
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/cffnpwr/git-cz-go/internal/interface/repo"
+	repoMock "github.com/cffnpwr/git-cz-go/internal/mock/repo"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"go.uber.org/mock/gomock"
+)
+
+func TestFormatStats(t *testing.T) {
+	tests := []struct {
+		name  string
+		stats repo.CommitStats
+		want  string
+	}{
+		{
+			name:  "[正常系] 変更なし",
+			stats: repo.CommitStats{},
+			want:  "0 files changed, 0 insertions(+)",
+		},
+		{
+			name:  "[正常系] 1ファイルの追加と削除",
+			stats: repo.CommitStats{FilesChanged: 1, Insertions: 1, Deletions: 1},
+			want:  "1 file changed, 1 insertion(+), 1 deletion(-)",
+		},
+		{
+			name:  "[正常系] 追加のみ",
+			stats: repo.CommitStats{FilesChanged: 2, Insertions: 5},
+			want:  "2 files changed, 5 insertions(+)",
+		},
+		{
+			name:  "[正常系] 削除のみ",
+			stats: repo.CommitStats{FilesChanged: 3, Deletions: 2},
+			want:  "3 files changed, 2 deletions(-)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatStats(tt.stats); got != tt.want {
+				t.Errorf("formatStats() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestModel_View_Summary(t *testing.T) {
+	const hash = "0123456789abcdef"
+
+	tests := []struct {
+		name        string
+		dryRun      bool
+		mockSetup   func(*repoMock.MockGitRepository)
+		wantOutcome OutcomeKind
+		want        []string
+		notWant     []string
+	}{
+		{
+			name: "[正常系] コミットした場合は概要を表示",
+			mockSetup: func(m *repoMock.MockGitRepository) {
+				m.EXPECT().Commit(gomock.Any()).Return(hash, nil)
+				m.EXPECT().GetCurrentBranch().Return("main", nil)
+				m.EXPECT().GetCommitStats(hash).Return(repo.CommitStats{FilesChanged: 1, Insertions: 2}, nil)
+			},
+			wantOutcome: OutcomeCommitted,
+			want:        []string{"Committed 0123456 on main", "fix it", "1 file changed, 2 insertions(+)", "git push"},
+			notWant:     []string{hash},
+		},
+		{
+			name: "[正常系] ブランチ名と変更量を取得できない場合は省略",
+			mockSetup: func(m *repoMock.MockGitRepository) {
+				m.EXPECT().Commit(gomock.Any()).Return(hash, nil)
+				m.EXPECT().GetCurrentBranch().Return("", errors.New("detached HEAD"))
+				m.EXPECT().GetCommitStats(hash).Return(repo.CommitStats{}, errors.New("no stats"))
+			},
+			wantOutcome: OutcomeCommitted,
+			want:        []string{"Committed 0123456", "fix it"},
+			notWant:     []string{" on ", "changed"},
+		},
+		{
+			name:        "[正常系] ドライランでは概要を表示しない",
+			dryRun:      true,
+			mockSetup:   func(m *repoMock.MockGitRepository) {},
+			wantOutcome: OutcomeConfirmed,
+			notWant:     []string{"Committed", "git push"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gitRepo := repoMock.NewMockGitRepository(ctrl)
+			tt.mockSetup(gitRepo)
+
+			cfg := createTestConfig()
+			cfg.SkipQuestions = []string{"co_authors"}
+			m, err := NewModel(cfg, gitRepo, lipgloss.DefaultRenderer())
+			if err != nil {
+				t.Fatalf("NewModel() error = %v", err)
+			}
+			var answered []Stage
+			for _, s := range stageOrder {
+				if s != StageConfirm {
+					answered = append(answered, s)
+				}
+			}
+			m = m.SetPrefill(Prefill{
+				Data:     CommitData{Type: "fix: :bug:", TicketNumber: "#1", Subject: "fix it"},
+				Answered: answered,
+			}).SetDryRun(tt.dryRun)
+
+			updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+			m = updated.(Model)
+
+			if got := m.GetOutcome().Kind; got != tt.wantOutcome {
+				t.Fatalf("GetOutcome() = %v, want %v", got, tt.wantOutcome)
+			}
+			view := ansi.Strip(m.View())
+			for _, s := range tt.want {
+				if !strings.Contains(view, s) {
+					t.Errorf("View() does not contain %q\n%s", s, view)
+				}
+			}
+			for _, s := range tt.notWant {
+				if strings.Contains(view, s) {
+					t.Errorf("View() contains %q\n%s", s, view)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package model
+
+import (
+	"strings"
+	"text/template"
+
+	"github.com/cffnpwr/git-cz-go/config"
+)
+
+// templateData is the value the message templates are executed with.
+// Every field of CommitData is available, `.Header` is the conventional header and `.Custom.<id>` the custom answers.
+type templateData struct {
+	CommitData
+	TypeName string // Conventional commit type such as `feat`
+}
+
+// FormatMessage renders the commit message with the templates in the config.
+// Sections without a template are written in the format of GenerateCommitMessage.
+func (cd CommitData) FormatMessage(cfg *config.Config) (string, error) {
+	data := cd.templateData(cfg)
+	header, err := renderSection("header", cfg.Template.Header, cd.Header(), data)
+	if err != nil {
+		return "", err
+	}
+	body, err := renderSection("body", cfg.Template.Body, cd.Body, data)
+	if err != nil {
+		return "", err
+	}
+	footer, err := renderSection("footer", cfg.Template.Footer, cd.footer(), data)
+	if err != nil {
+		return "", err
+	}
+	return joinSections(header, body, footer), nil
+}
+
+// FormatHeader renders the first line of the commit message, falling back to the conventional header when the template fails
+func (cd CommitData) FormatHeader(cfg *config.Config) string {
+	header, err := renderSection("header", cfg.Template.Header, cd.Header(), cd.templateData(cfg))
+	if err != nil {
+		return cd.Header()
+	}
+	return header
+}
+
+func (cd CommitData) templateData(cfg *config.Config) templateData {
+	typeValue, _ := findTypeValue(cfg, cd.Type)
+	cd.Custom = cd.templateAnswers(cfg)
+	return templateData{CommitData: cd, TypeName: typeValue.TypeName()}
+}
+
+// renderSection はテンプレートが設定されていればそれで、無ければ既定の内容でセクションを作る
+// 前後の空白は取り除く
+func renderSection(name, src, fallback string, data templateData) (string, error) {
+	if src == "" {
+		return fallback, nil
+	}
+	tmpl, err := template.New(name).Funcs(config.TemplateFuncs).Parse(src)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(b.String()), nil
+}
+
+// joinSections は空でないセクションを空行で区切って連結する
+func joinSections(header string, sections ...string) string {
+	parts := []string{header}
+	for _, s := range sections {
+		if s != "" {
+			parts = append(parts, s)
+		}
+	}
+	return strings.Join(parts, "\n\n")
+}
@@ -0,0 +1,61 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/cffnpwr/git-cz-go/config"
+)
+
+func TestCommitData_FormatMessage(t *testing.T) {
+	cd := CommitData{
+		Type:      "feat: :sparkles:",
+		Subject:   "add form",
+		Body:      "details",
+		CoAuthors: []string{"Alice <alice@example.com>"},
+		Custom:    map[string]any{"tested_on": "linux", "risk": "high"},
+	}
+
+	tests := []struct {
+		name     string
+		template config.Template
+		want     string
+		wantErr  bool
+	}{
+		{
+			name: "[正常系] テンプレートが無い場合は既定の形式",
+			want: "feat: :sparkles:: add form\n\ndetails\n\nCo-authored-by: Alice <alice@example.com>",
+		},
+		{
+			name: "[正常系] カスタム質問の回答を参照する",
+			template: config.Template{
+				Body:   "{{ .Body }}\n\nTested on: {{ .Custom.tested_on }}",
+				Footer: "Risk: {{ .Custom.risk }}{{ range .CoAuthors }}\nCo-authored-by: {{ . }}{{ end }}",
+			},
+			want: "feat: :sparkles:: add form\n\ndetails\n\nTested on: linux\n\nRisk: high\nCo-authored-by: Alice <alice@example.com>",
+		},
+		{
+			name:     "[正常系] ヘッダーを組み込みの項目から作る",
+			template: config.Template{Header: "{{ .TypeName }}: {{ upper .Subject }}", Body: "{{ if .Custom.rollback }}rollback{{ end }}"},
+			want:     "feat: ADD FORM\n\nCo-authored-by: Alice <alice@example.com>",
+		},
+		{
+			name:     "[異常系] テンプレートの実行に失敗",
+			template: config.Template{Header: "{{ .Unknown }}"},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createCustomQuestionsConfig()
+			cfg.Template = tt.template
+			got, err := cd.FormatMessage(cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FormatMessage() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("FormatMessage() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package model
+
+import (
+	"github.com/cffnpwr/git-cz-go/config"
+	"github.com/cffnpwr/git-cz-go/pkg/theme"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// newTheme builds the theme from the config, dropping the colors when NO_COLOR is set
+func newTheme(cfg config.Theme) theme.Theme {
+	t := theme.Default
+	if p, ok := theme.Preset(cfg.Preset); ok {
+		t = p
+	}
+	noColor := theme.NoColor()
+	if noColor {
+		t = theme.None
+	}
+	// 枠線の指定は色と関係ないため NO_COLOR でも残す
+	if b, ok := theme.Border(cfg.Border); ok {
+		t.Border = b
+	}
+	if noColor {
+		return t
+	}
+
+	// 設定された色だけで上書きする
+	for _, c := range []struct {
+		value  string
+		target *lipgloss.TerminalColor
+	}{
+		{cfg.Accent, &t.Accent},
+		{cfg.Muted, &t.Muted},
+		{cfg.Error, &t.Error},
+		{cfg.Warning, &t.Warning},
+		{cfg.Group, &t.Group},
+		{cfg.Description, &t.Description},
+		{cfg.ButtonFg, &t.ButtonFg},
+		{cfg.ButtonBg, &t.ButtonBg},
+		{cfg.ButtonIdleBg, &t.ButtonIdleBg},
+	} {
+		if c.value != "" {
+			*c.target = lipgloss.Color(c.value)
+		}
+	}
+	return t
+}
+
+// Styles are the styles of the wizard built from a theme
+type Styles struct {
+	Prompt       lipgloss.Style // Prompts of the questions and the branch in the summary
+	Icon         lipgloss.Style // Icon in front of each question
+	Value        lipgloss.Style // Answers of the finished questions
+	Info         lipgloss.Style // Hints and counters
+	Warning      lipgloss.Style // Warning violations
+	Error        lipgloss.Style // Errors and error violations
+	Message      lipgloss.Style // Commit message shown on the confirmation
+	PreviewPanel lipgloss.Style // Panel of the live preview
+	PreviewTitle lipgloss.Style // Title of the live preview
+	Hash         lipgloss.Style // Commit hash in the summary
+	SummaryBody  lipgloss.Style // Lines under the title of the summary
+}
+
+// NewStyles builds the styles of the wizard on r with the colors and the border of t
+func NewStyles(r *lipgloss.Renderer, t theme.Theme) Styles {
+	return Styles{
+		Prompt:       r.NewStyle().Bold(true),
+		Icon:         r.NewStyle().Foreground(t.Accent).Bold(true).MarginRight(1),
+		Value:        r.NewStyle().Foreground(t.Accent),
+		Info:         r.NewStyle().Foreground(t.Muted),
+		Warning:      r.NewStyle().Foreground(t.Warning),
+		Error:        r.NewStyle().Foreground(t.Error),
+		Message:      r.NewStyle().Bold(true).Padding(0, 2).Margin(0, 1).Border(t.Border),
+		PreviewPanel: r.NewStyle().Padding(0, 1).Border(t.Border).BorderForeground(t.Muted),
+		PreviewTitle: r.NewStyle().Foreground(t.Accent).Bold(true),
+		Hash:         r.NewStyle().Foreground(t.Accent).Bold(true),
+		SummaryBody:  r.NewStyle().PaddingLeft(2),
+	}
+}
+
+// violation renders a violation message marked as an error or a warning
+func (s Styles) violation(v Violation) string {
+	if v.Warning {
+		return s.Warning.Render("⚠ " + v.Message)
+	}
+	return s.Error.Render("✕ " + v.Message)
+}
@@ -0,0 +1,137 @@
+package model
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/cffnpwr/git-cz-go/config"
+	"github.com/cffnpwr/git-cz-go/pkg/theme"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/google/go-cmp/cmp"
+	"github.com/muesli/termenv"
+)
+
+func TestNewTheme(t *testing.T) {
+	withAccent := theme.Dark
+	withAccent.Accent = lipgloss.Color("#00ff00")
+	withListColors := theme.Light
+	withListColors.Group = lipgloss.Color("4")
+	withListColors.Description = lipgloss.Color("8")
+	withListColors.ButtonIdleBg = lipgloss.Color("#dddddd")
+	noneThick := theme.None
+	noneThick.Border = lipgloss.ThickBorder()
+
+	tests := []struct {
+		name    string
+		cfg     config.Theme
+		noColor string
+		want    theme.Theme
+	}{
+		{
+			name: "[正常系] 設定がなければ既定のテーマ",
+			want: theme.Default,
+		},
+		{
+			name: "[正常系] プリセットの色を上書き",
+			cfg:  config.Theme{Preset: "dark", Accent: "#00ff00"},
+			want: withAccent,
+		},
+		{
+			name: "[正常系] 見出し・説明・選択されていないボタンの色を上書き",
+			cfg:  config.Theme{Preset: "light", Group: "4", Description: "8", ButtonIdleBg: "#dddddd"},
+			want: withListColors,
+		},
+		{
+			name:    "[正常系] NO_COLORではプリセットより色の無いテーマを優先",
+			cfg:     config.Theme{Preset: "dark"},
+			noColor: "1",
+			want:    theme.None,
+		},
+		{
+			name:    "[正常系] NO_COLORでは色を使わず枠線の指定は残す",
+			cfg:     config.Theme{Preset: "dark", Accent: "#00ff00", Border: "thick"},
+			noColor: "1",
+			want:    noneThick,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("NO_COLOR", tt.noColor)
+			if diff := cmp.Diff(tt.want, newTheme(tt.cfg)); diff != "" {
+				t.Errorf("newTheme() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNewModel_Styles(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+
+	// 同じプロセスで作った別のモデルのテーマに影響されない
+	darkCfg := createTestConfig()
+	darkCfg.Theme = config.Theme{Preset: "dark", Accent: "#ff0000"}
+	dark := createTestPreviewModel(t, darkCfg)
+	lightCfg := createTestConfig()
+	lightCfg.Theme = config.Theme{Preset: "light", Accent: "#0000ff"}
+	light := createTestPreviewModel(t, lightCfg)
+
+	tests := []struct {
+		name  string
+		model Model
+		want  lipgloss.TerminalColor
+	}{
+		{
+			name:  "[正常系] 先に作ったモデルは自身のテーマの色を使う",
+			model: dark,
+			want:  lipgloss.Color("#ff0000"),
+		},
+		{
+			name:  "[正常系] 後に作ったモデルは自身のテーマの色を使う",
+			model: light,
+			want:  lipgloss.Color("#0000ff"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, tt.model.styles.Icon.GetForeground()); diff != "" {
+				t.Errorf("Icon foreground mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.want, tt.model.styles.PreviewTitle.GetForeground()); diff != "" {
+				t.Errorf("PreviewTitle foreground mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNewStyles(t *testing.T) {
+	tests := []struct {
+		name      string
+		profile   termenv.Profile
+		wantColor bool
+	}{
+		{
+			name:      "[正常系] 色を扱える出力の renderer では色を付ける",
+			profile:   termenv.TrueColor,
+			wantColor: true,
+		},
+		{
+			name:      "[正常系] 色を扱えない出力の renderer では色を付けない",
+			profile:   termenv.Ascii,
+			wantColor: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := lipgloss.NewRenderer(io.Discard)
+			r.SetColorProfile(tt.profile)
+			view := NewStyles(r, theme.Dark).Icon.Render(defaultIconCharQuestion)
+			if got := strings.Contains(view, "38;2;"); got != tt.wantColor {
+				t.Errorf("Render() colored = %v, want %v: %q", got, tt.wantColor, view)
+			}
+		})
+	}
+}
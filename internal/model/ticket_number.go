@@ -6,6 +6,7 @@ import (
 
 	"github.com/cffnpwr/git-cz-go/config"
 	"github.com/cffnpwr/git-cz-go/internal/interface/repo"
+	"github.com/cffnpwr/git-cz-go/pkg/theme"
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
@@ -16,13 +17,6 @@ const (
 	defaultTicketPrompt = "Enter ticket number"
 )
 
-var (
-	infoColor  = lipgloss.Color("#696969")
-	errorColor = lipgloss.Color("#ff0000")
-	infoStyle  = lipgloss.NewStyle().Foreground(infoColor)
-	errorStyle = lipgloss.NewStyle().Foreground(errorColor)
-)
-
 type tnValidationResult struct {
 	valid    bool
 	errorMsg string
@@ -32,6 +26,8 @@ type TicketNumberModel struct {
 	input    textinput.Model
 	config   config.TicketNumber
 	gitRepo  repo.GitRepository
+	keys     KeyMap
+	styles   Styles
 	finished bool
 	valid    bool
 	errorMsg string
@@ -52,6 +48,8 @@ func NewTicketNumberModel(tnPrompt string, tnCfg config.TicketNumber, gitRepo re
 		input:   input,
 		config:  tnCfg,
 		gitRepo: gitRepo,
+		keys:    DefaultKeyMap,
+		styles:  NewStyles(lipgloss.DefaultRenderer(), theme.Default),
 	}
 
 	// ブランチ名から自動抽出
@@ -65,23 +63,53 @@ func NewTicketNumberModel(tnPrompt string, tnCfg config.TicketNumber, gitRepo re
 	return model
 }
 
+// SetKeyMap sets the keys used to finish the input
+func (m TicketNumberModel) SetKeyMap(km KeyMap) TicketNumberModel {
+	m.keys = km
+	return m
+}
+
+// SetStyles sets the styles of the messages under the input
+func (m TicketNumberModel) SetStyles(s Styles) TicketNumberModel {
+	m.styles = s
+	return m
+}
+
+// SetWidth fits the input into the width
+func (m TicketNumberModel) SetWidth(w int) TicketNumberModel {
+	m.input = fitInput(m.input, w)
+	return m
+}
+
+// ShortHelp returns the keys shown in the help line, implementing help.KeyMap
+func (m TicketNumberModel) ShortHelp() []key.Binding {
+	return []key.Binding{m.keys.Enter}
+}
+
+// FullHelp returns the keys shown in the full help, implementing help.KeyMap
+func (m TicketNumberModel) FullHelp() [][]key.Binding {
+	return [][]key.Binding{m.ShortHelp()}
+}
+
 func (m TicketNumberModel) GetPrompt() string {
 	return m.input.Prompt
 }
 
 func (m TicketNumberModel) GetValue() string {
-	value := strings.TrimSpace(m.input.Value())
-	if value != "" && m.config.Prefix != "" {
-		return m.config.Prefix + value
-	}
-	return value
+	return formatTicketNumber(m.config, m.input.Value())
 }
 
 func (m TicketNumberModel) IsFinished() bool {
 	return m.finished
 }
 
-func (m TicketNumberModel) Focus() {
+// Reset makes the model editable again while keeping the entered value
+func (m TicketNumberModel) Reset() TicketNumberModel {
+	m.finished = false
+	return m
+}
+
+func (m *TicketNumberModel) Focus() {
 	m.input.Focus()
 }
 
@@ -96,7 +124,7 @@ func (m TicketNumberModel) Update(msg tea.Msg) (TicketNumberModel, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		if key.Matches(msg, enterKey) && m.valid {
+		if key.Matches(msg, m.keys.Enter) && m.valid {
 			m.finished = true
 			return m, nil
 		}
@@ -115,32 +143,43 @@ func (m TicketNumberModel) View() string {
 	view := m.input.View()
 
 	if m.errorMsg != "" {
-		view += "\n" + errorStyle.Render("✕ "+m.errorMsg)
+		view += "\n" + m.styles.Error.Render("✕ "+m.errorMsg)
 	}
 
 	if m.valid && !m.finished {
-		view += "\n" + infoStyle.Render("Press Enter to continue")
+		view += "\n" + m.styles.Info.Render("Press Enter to continue")
 	}
 
 	return view
 }
 
 func (m TicketNumberModel) validateInput() tnValidationResult {
-	value := strings.TrimSpace(m.input.Value())
+	return validateTicketNumber(m.config, m.input.Value())
+}
+
+// validateTicketNumber はプレフィックスを除いたチケット番号を設定に従って検証する
+func validateTicketNumber(tnCfg config.TicketNumber, value string) tnValidationResult {
+	value = strings.TrimSpace(value)
 
-	if !m.config.Required {
+	if !tnCfg.Required {
 		return tnValidationResult{
 			valid: true,
 		}
 	}
-	if m.config.MatchPattern == nil && value == "" {
+	// パターンが設定されている場合は空の値もパターンで検証する
+	if tnCfg.MatchPattern == nil && value == "" {
 		return tnValidationResult{
 			valid:    false,
 			errorMsg: "Ticket number is required",
 		}
 	}
+	if tnCfg.MatchPattern == nil {
+		return tnValidationResult{
+			valid: true,
+		}
+	}
 
-	re := (*regexp.Regexp)(m.config.MatchPattern)
+	re := (*regexp.Regexp)(tnCfg.MatchPattern)
 	if !re.MatchString(value) {
 		return tnValidationResult{
 			valid:    false,
@@ -153,18 +192,31 @@ func (m TicketNumberModel) validateInput() tnValidationResult {
 }
 
 func (m TicketNumberModel) extractTicketFromBranch() string {
-	if !m.config.FromBranchName.Enable || m.config.FromBranchName.ExtractRegexp == nil {
+	return extractTicketFromBranch(m.config, m.gitRepo)
+}
+
+// formatTicketNumber は入力されたチケット番号にプレフィックスを付与する
+func formatTicketNumber(tnCfg config.TicketNumber, value string) string {
+	value = strings.TrimSpace(value)
+	if value != "" && tnCfg.Prefix != "" {
+		return tnCfg.Prefix + value
+	}
+	return value
+}
+
+func extractTicketFromBranch(tnCfg config.TicketNumber, gitRepo repo.GitRepository) string {
+	if !tnCfg.FromBranchName.Enable || tnCfg.FromBranchName.ExtractRegexp == nil {
 		return ""
 	}
 
 	// Repository経由でブランチ名を取得
-	branchName, err := m.gitRepo.GetCurrentBranch()
+	branchName, err := gitRepo.GetCurrentBranch()
 	if err != nil {
 		return ""
 	}
 
 	// 正規表現で抽出
-	re := (*regexp.Regexp)(m.config.FromBranchName.ExtractRegexp)
+	re := (*regexp.Regexp)(tnCfg.FromBranchName.ExtractRegexp)
 	matches := re.FindStringSubmatch(branchName)
 	if len(matches) > 1 {
 		// 名前付きキャプチャグループから抽出
@@ -37,6 +37,7 @@ func createTestTicketNumberModel() TicketNumberModel {
 		}(),
 		config:   config.TicketNumber{},
 		gitRepo:  mockRepo,
+		keys:     DefaultKeyMap,
 		finished: false,
 		valid:    true,
 		errorMsg: "",
@@ -154,6 +155,31 @@ func TestTicketNumberModel_validateInput(t *testing.T) {
 			expectedValid: false,
 			expectedError: "Invalid ticket number format",
 		},
+		{
+			name: "[異常系] 空値、必須でパターンがある場合はパターンで検証",
+			config: config.TicketNumber{
+				Required:     true,
+				MatchPattern: (*config.Regexp)(regexp.MustCompile(`\d+`)),
+			},
+			inputValue:    "",
+			expectedValid: false,
+			expectedError: "Invalid ticket number format",
+		},
+		{
+			name: "[正常系] 空値、空にマッチするパターン",
+			config: config.TicketNumber{
+				Required:     true,
+				MatchPattern: (*config.Regexp)(regexp.MustCompile(`^\d*$`)),
+			},
+			inputValue:    "",
+			expectedValid: true,
+		},
+		{
+			name:          "[正常系] パターンが無い場合は空でなければ有効",
+			config:        config.TicketNumber{Required: true},
+			inputValue:    "123",
+			expectedValid: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -242,6 +268,7 @@ func TestTicketNumberModel_Update(t *testing.T) {
 				model := TicketNumberModel{
 					config:   config.TicketNumber{Required: false},
 					gitRepo:  repo,
+					keys:     DefaultKeyMap,
 					valid:    true,
 					finished: false,
 				}
@@ -0,0 +1,118 @@
+package model
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/cffnpwr/git-cz-go/config"
+)
+
+// stageLabels are the names of the stages used in messages
+var stageLabels = map[Stage]string{
+	StageScope:        "Scope",
+	StageTicketNumber: "Ticket number",
+	StageBody:         "Body",
+	StageFooter:       "Footer",
+	StageCoAuthors:    "Co-authors",
+}
+
+// isSkippedForType reports whether the stage is not asked for the type.
+// Breaking changes are only asked for the types listed in allow_breaking_changes when the list is set.
+func isSkippedForType(cfg *config.Config, typeValue string, stage Stage) bool {
+	tv, ok := findTypeValue(cfg, typeValue)
+	if !ok {
+		return false
+	}
+	if slices.Contains(tv.SkipQuestions, string(stage)) {
+		return true
+	}
+	return stage == StageBreaking && len(cfg.AllowBreakingChanges) > 0 &&
+		!slices.Contains(cfg.AllowBreakingChanges, tv.TypeName())
+}
+
+// isRequiredForType reports whether the type requires an answer to the stage
+func isRequiredForType(cfg *config.Config, typeValue string, stage Stage) bool {
+	tv, ok := findTypeValue(cfg, typeValue)
+	return ok && slices.Contains(tv.RequiredQuestions, string(stage))
+}
+
+// lintRequired はタイプで必須とされたステージが空でないかを検証する
+// 本文はテンプレートの見出しと合わせてlintBodySectionsで検証する
+func (cd CommitData) lintRequired(cfg *config.Config, stage Stage) []Violation {
+	if stage == StageBody || !isRequiredForType(cfg, cd.Type, stage) {
+		return nil
+	}
+	if strings.TrimSpace(cd.stageValue(stage)) != "" {
+		return nil
+	}
+
+	tv, _ := findTypeValue(cfg, cd.Type)
+	return []Violation{{Stage: stage, Message: stageLabels[stage] + " is required for type: " + tv.TypeName()}}
+}
+
+// stageValue はステージの回答を文字列として返す
+func (cd CommitData) stageValue(stage Stage) string {
+	switch stage {
+	case StageTypeSelect:
+		return cd.Type
+	case StageScope:
+		return cd.Scope
+	case StageTicketNumber:
+		return cd.TicketNumber
+	case StageSubject:
+		return cd.Subject
+	case StageBody:
+		return cd.Body
+	case StageBreaking:
+		return cd.BreakingChanges
+	case StageFooter:
+		return cd.Footer
+	case StageCoAuthors:
+		return strings.Join(cd.CoAuthors, ", ")
+	}
+	return ""
+}
+
+// clearSkippedForType はタイプで質問されないステージの回答を消去する
+// keep に含まれるステージ（事前に回答されたもの）は残す
+func (cd CommitData) clearSkippedForType(cfg *config.Config, keep map[Stage]bool) CommitData {
+	for _, stage := range stageOrder {
+		if keep[stage] || !isSkippedForType(cfg, cd.Type, stage) {
+			continue
+		}
+		switch stage {
+		case StageScope:
+			cd.Scope = ""
+		case StageTicketNumber:
+			cd.TicketNumber = ""
+		case StageBody:
+			cd.Body = ""
+		case StageBreaking:
+			cd.BreakingChanges = ""
+			cd.IsBreaking = false
+		case StageFooter:
+			cd.Footer = ""
+		case StageCoAuthors:
+			cd.CoAuthors = nil
+		}
+	}
+	return cd
+}
+
+// applyTypeDefaults はタイプで質問されるステージのうち空の回答に既定値を設定する
+func (cd CommitData) applyTypeDefaults(cfg *config.Config) CommitData {
+	tv, ok := findTypeValue(cfg, cd.Type)
+	if !ok {
+		return cd
+	}
+	set := func(stage Stage, value *string, def string) {
+		if *value == "" && !isSkippedForType(cfg, cd.Type, stage) {
+			*value = def
+		}
+	}
+	set(StageScope, &cd.Scope, tv.Defaults.Scope)
+	set(StageTicketNumber, &cd.TicketNumber, tv.Defaults.TicketNumber)
+	set(StageBody, &cd.Body, tv.Defaults.Body)
+	set(StageFooter, &cd.Footer, tv.Defaults.Footer)
+	return cd
+}
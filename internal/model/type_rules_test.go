@@ -0,0 +1,137 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/cffnpwr/git-cz-go/config"
+	"github.com/google/go-cmp/cmp"
+)
+
+func createTypeRulesConfig() *config.Config {
+	cfg := createTestConfig()
+	cfg.Types = append(cfg.Types,
+		config.TypeValue{Value: "revert: :rewind:", Name: "revert", RequiredQuestions: []string{"body"}},
+		config.TypeValue{Value: "chore: :wrench:", Name: "chore", RequiredQuestions: []string{"scope"},
+			Defaults: config.QuestionDefaults{Scope: "deps", Footer: "Refs: #1"}},
+	)
+	cfg.Types[2].SkipQuestions = config.SkipQuestions{"ticket_number", "breaking"}
+	return cfg
+}
+
+func TestIsSkippedForType(t *testing.T) {
+	tests := []struct {
+		name      string
+		typeValue string
+		stage     Stage
+		want      bool
+	}{
+		{
+			name:      "[正常系] 破壊的変更を許可されたタイプ",
+			typeValue: "feat: :sparkles:",
+			stage:     StageBreaking,
+			want:      false,
+		},
+		{
+			name:      "[正常系] 破壊的変更を許可されていないタイプ",
+			typeValue: "revert: :rewind:",
+			stage:     StageBreaking,
+			want:      true,
+		},
+		{
+			name:      "[正常系] タイプごとに省略する質問",
+			typeValue: "docs: :memo:",
+			stage:     StageTicketNumber,
+			want:      true,
+		},
+		{
+			name:      "[正常系] タイプごとに省略しない質問",
+			typeValue: "docs: :memo:",
+			stage:     StageScope,
+			want:      false,
+		},
+		{
+			name:      "[正常系] 存在しないタイプ",
+			typeValue: "perf",
+			stage:     StageBreaking,
+			want:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isSkippedForType(createTypeRulesConfig(), tt.typeValue, tt.stage); got != tt.want {
+				t.Errorf("isSkippedForType() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommitData_Lint_TypeRules(t *testing.T) {
+	tests := []struct {
+		name string
+		cd   CommitData
+		want []Violation
+	}{
+		{
+			name: "[正常系] 省略されたチケット番号は必須としない",
+			cd:   CommitData{Type: "docs: :memo:", Subject: "update readme"},
+		},
+		{
+			name: "[異常系] 破壊的変更を許可されていないタイプ",
+			cd:   CommitData{Type: "docs: :memo:", Subject: "update readme", IsBreaking: true},
+			want: []Violation{{Stage: StageBreaking, Message: "Breaking changes are not allowed for type: docs"}},
+		},
+		{
+			name: "[異常系] タイプで必須とされた本文がない",
+			cd:   CommitData{Type: "revert: :rewind:", Subject: "revert feature", TicketNumber: "#1"},
+			want: []Violation{{Stage: StageBody, Message: "Body is required for type: revert"}},
+		},
+		{
+			name: "[異常系] タイプで必須とされたスコープがない",
+			cd:   CommitData{Type: "chore: :wrench:", Subject: "bump", TicketNumber: "#1"},
+			want: []Violation{{Stage: StageScope, Message: "Scope is required for type: chore"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.cd.Lint(createTypeRulesConfig())
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Lint() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCommitData_applyTypeDefaults(t *testing.T) {
+	tests := []struct {
+		name string
+		cd   CommitData
+		want CommitData
+	}{
+		{
+			name: "[正常系] 空の回答に既定値を設定",
+			cd:   CommitData{Type: "chore: :wrench:"},
+			want: CommitData{Type: "chore: :wrench:", Scope: "deps", Footer: "Refs: #1"},
+		},
+		{
+			name: "[正常系] 入力済みの回答は変更しない",
+			cd:   CommitData{Type: "chore: :wrench:", Scope: "ci"},
+			want: CommitData{Type: "chore: :wrench:", Scope: "ci", Footer: "Refs: #1"},
+		},
+		{
+			name: "[正常系] 既定値のないタイプ",
+			cd:   CommitData{Type: "feat: :sparkles:"},
+			want: CommitData{Type: "feat: :sparkles:"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.cd.applyTypeDefaults(createTypeRulesConfig())
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("applyTypeDefaults() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
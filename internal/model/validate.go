@@ -0,0 +1,163 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cffnpwr/git-cz-go/config"
+)
+
+// Violation is a single rule violation found while validating commit data
+type Violation struct {
+	Stage   Stage
+	Message string
+	Warning bool // The violation is reported but does not block the commit
+}
+
+func (v Violation) String() string {
+	return string(v.Stage) + ": " + v.Message
+}
+
+// ResolveType finds the configured type value matching s.
+// s may be either the full value (e.g. `feat: :sparkles:`) or the bare type name (e.g. `feat`).
+func ResolveType(cfg *config.Config, s string) (string, error) {
+	s = strings.TrimSpace(s)
+	for _, t := range cfg.Types {
+		if t.Value == s {
+			return t.Value, nil
+		}
+	}
+	// タイプ名での指定は無効なタイプを除いて解決する
+	for _, t := range cfg.Types {
+		if t.TypeName() == s && !t.Disabled {
+			return t.Value, nil
+		}
+	}
+	return "", fmt.Errorf("unknown commit type: %s", s)
+}
+
+// FormatTicketNumber adds the configured prefix to a ticket number unless it already has it
+func FormatTicketNumber(cfg *config.Config, value string) string {
+	value = strings.TrimPrefix(strings.TrimSpace(value), cfg.TicketNumber.Prefix)
+	return formatTicketNumber(cfg.TicketNumber, value)
+}
+
+// Lint checks every stage of the commit data against the config
+func (cd CommitData) Lint(cfg *config.Config) []Violation {
+	var violations []Violation
+	for _, stage := range stageOrder {
+		violations = append(violations, cd.lintStage(cfg, stage)...)
+	}
+	violations = append(violations, cd.lintCustom(cfg)...)
+	if _, err := cd.FormatMessage(cfg); err != nil {
+		violations = append(violations, Violation{Stage: StageConfirm, Message: "Failed to render the message: " + err.Error()})
+	}
+	return violations
+}
+
+// Validate returns an error describing every violation found by Lint, ignoring warnings
+func (cd CommitData) Validate(cfg *config.Config) error {
+	return violationsError(cd.Lint(cfg))
+}
+
+// Warnings returns the violations found by Lint that do not block the commit
+func (cd CommitData) Warnings(cfg *config.Config) []Violation {
+	var warnings []Violation
+	for _, v := range cd.Lint(cfg) {
+		if v.Warning {
+			warnings = append(warnings, v)
+		}
+	}
+	return warnings
+}
+
+// lintStage は1つのステージで入力される値を検証する
+func (cd CommitData) lintStage(cfg *config.Config, stage Stage) []Violation {
+	var violations []Violation
+	add := func(msg string) {
+		violations = append(violations, Violation{Stage: stage, Message: msg})
+	}
+
+	// タイプで質問されないステージは回答されていても検証しない
+	if stage != StageTypeSelect && isSkippedForType(cfg, cd.Type, stage) {
+		if stage == StageBreaking && cd.IsBreaking {
+			typeValue, _ := findTypeValue(cfg, cd.Type)
+			add("Breaking changes are not allowed for type: " + typeValue.TypeName())
+		}
+		return violations
+	}
+	violations = append(violations, cd.lintRequired(cfg, stage)...)
+
+	switch stage {
+	case StageTypeSelect:
+		if cd.Type == "" {
+			add("Type is required")
+		} else if typeValue, ok := findTypeValue(cfg, cd.Type); !ok {
+			add("Unknown type: " + cd.Type)
+		} else if typeValue.Disabled {
+			add("Type is disabled: " + cd.Type)
+		}
+	case StageTicketNumber:
+		if !cfg.TicketNumber.Enable {
+			break
+		}
+		value := strings.TrimPrefix(cd.TicketNumber, cfg.TicketNumber.Prefix)
+		if res := validateTicketNumber(cfg.TicketNumber, value); !res.valid {
+			add(res.errorMsg)
+		}
+	case StageSubject:
+		violations = append(violations, lintHeader(cfg.Header, cd)...)
+	case StageBody:
+		if typeValue, ok := findTypeValue(cfg, cd.Type); ok {
+			violations = append(violations, lintBodySections(typeValue, cd.Body)...)
+		}
+		violations = append(violations, lintBody(cfg.Body, cd.Body)...)
+	case StageFooter:
+		if strings.TrimSpace(cd.Footer) == "" {
+			break
+		}
+		if res := validateFooter(cd.Footer); !res.valid {
+			add(res.errorMsg)
+		}
+	case StageCoAuthors:
+		// 回答ファイルなどで指定された共同作成者も設定と同じ形式で検証する
+		for _, c := range cd.CoAuthors {
+			var coAuthor config.CoAuthor
+			if err := coAuthor.UnmarshalText([]byte(c)); err != nil {
+				add(err.Error())
+			}
+		}
+	}
+	return violations
+}
+
+// hasErrors reports whether any of the violations blocks the commit
+func hasErrors(violations []Violation) bool {
+	for _, v := range violations {
+		if !v.Warning {
+			return true
+		}
+	}
+	return false
+}
+
+func findTypeValue(cfg *config.Config, value string) (config.TypeValue, bool) {
+	for _, t := range cfg.Types {
+		if t.Value == value {
+			return t, true
+		}
+	}
+	return config.TypeValue{}, false
+}
+
+// violationsError は警告を除いた違反をまとめたエラーを返す
+func violationsError(violations []Violation) error {
+	var errs []error
+	for _, v := range violations {
+		if !v.Warning {
+			errs = append(errs, errors.New(v.String()))
+		}
+	}
+	return errors.Join(errs...)
+}
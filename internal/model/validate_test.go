@@ -0,0 +1,175 @@
+package model
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/cffnpwr/git-cz-go/config"
+	"github.com/google/go-cmp/cmp"
+)
+
+func createTestConfig() *config.Config {
+	return &config.Config{
+		Types: []config.TypeValue{
+			{Value: "feat: :sparkles:", Name: "feat"},
+			{Value: "fix: :bug:", Name: "fix"},
+			{Value: "docs: :memo:", Name: "docs"},
+		},
+		AllowBreakingChanges: []string{"feat", "fix"},
+		TicketNumber: config.TicketNumber{
+			Enable:       true,
+			Required:     true,
+			Prefix:       "#",
+			MatchPattern: (*config.Regexp)(regexp.MustCompile(`^\d+$`)),
+		},
+	}
+}
+
+func TestResolveType(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "[正常系] 完全な値で指定",
+			input: "fix: :bug:",
+			want:  "fix: :bug:",
+		},
+		{
+			name:  "[正常系] タイプ名で指定",
+			input: "docs",
+			want:  "docs: :memo:",
+		},
+		{
+			name:    "[異常系] 存在しないタイプ",
+			input:   "perf",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveType(createTestConfig(), tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ResolveType() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ResolveType() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommitData_Lint(t *testing.T) {
+	tests := []struct {
+		name string
+		data CommitData
+		want []Violation
+	}{
+		{
+			name: "[正常系] 全て有効",
+			data: CommitData{
+				Type:         "feat: :sparkles:",
+				TicketNumber: "#123",
+				Subject:      "add feature",
+				Footer:       "Refs: #123",
+				IsBreaking:   true,
+				CoAuthors:    []string{"Alice <alice@example.com>"},
+			},
+		},
+		{
+			name: "[異常系] 必須項目が空",
+			data: CommitData{},
+			want: []Violation{
+				{Stage: StageTypeSelect, Message: "Type is required"},
+				// パターンが設定されている場合は空の値もパターンで検証する
+				{Stage: StageTicketNumber, Message: "Invalid ticket number format"},
+				{Stage: StageSubject, Message: "Subject is required"},
+			},
+		},
+		{
+			name: "[異常系] 不正な値",
+			data: CommitData{
+				Type:         "docs: :memo:",
+				TicketNumber: "#abc",
+				Subject:      "update readme",
+				Footer:       "invalid footer",
+				IsBreaking:   true,
+			},
+			want: []Violation{
+				{Stage: StageTicketNumber, Message: "Invalid ticket number format"},
+				{Stage: StageBreaking, Message: "Breaking changes are not allowed for type: docs"},
+				{Stage: StageFooter, Message: "Footer must start with 'word: ' or 'word # ' format"},
+			},
+		},
+		{
+			name: "[異常系] 不正な形式の共同作成者",
+			data: CommitData{
+				Type:         "feat: :sparkles:",
+				TicketNumber: "#123",
+				Subject:      "add feature",
+				CoAuthors:    []string{"Alice <alice@example.com>", "bob"},
+			},
+			want: []Violation{
+				{Stage: StageCoAuthors, Message: `invalid co-author "bob", must be 'Name <email>'`},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.data.Lint(createTestConfig())
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Lint() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDisabledType(t *testing.T) {
+	cfg := createTestConfig()
+	cfg.Types = []config.TypeValue{
+		{Value: "feat: :boom:", Name: "feat boom", Disabled: true},
+		{Value: "feat: :sparkles:", Name: "feat"},
+	}
+
+	tests := []struct {
+		name      string
+		input     string
+		wantType  string
+		wantLints []Violation
+	}{
+		{
+			name:     "[正常系] タイプ名では無効なタイプを選ばない",
+			input:    "feat",
+			wantType: "feat: :sparkles:",
+		},
+		{
+			name:     "[異常系] 無効なタイプを値で指定",
+			input:    "feat: :boom:",
+			wantType: "feat: :boom:",
+			wantLints: []Violation{
+				{Stage: StageTypeSelect, Message: "Type is disabled: feat: :boom:"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveType(cfg, tt.input)
+			if err != nil {
+				t.Fatalf("ResolveType() error = %v", err)
+			}
+			if got != tt.wantType {
+				t.Errorf("ResolveType() = %q, want %q", got, tt.wantType)
+			}
+
+			lints := CommitData{Type: got}.lintStage(cfg, StageTypeSelect)
+			if diff := cmp.Diff(tt.wantLints, lints); diff != "" {
+				t.Errorf("lintStage() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
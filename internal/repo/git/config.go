@@ -39,6 +39,14 @@ func (g *GitConfigReaderImpl) GetUserEmail() (string, error) {
 	return email, nil
 }
 
+func (g *GitConfigReaderImpl) GetEditor() (string, error) {
+	editor := g.config.Get("core.editor")
+	if editor == "" {
+		return "", errors.New("core.editor is not configured")
+	}
+	return editor, nil
+}
+
 func (g *GitConfigReaderImpl) CreateSignature() (*object.Signature, error) {
 	name, err := g.GetUserName()
 	if err != nil {
@@ -3,12 +3,25 @@ package git
 import (
 	"errors"
 	"fmt"
+	"os"
+	"regexp"
+	"strings"
 
 	gitIF "github.com/cffnpwr/git-cz-go/internal/interface/git"
 	"github.com/cffnpwr/git-cz-go/internal/interface/repo"
 	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
 )
 
+// defaultEditor is used when no editor is configured, as git does
+const defaultEditor = "vi"
+
+// coAuthorTrailerPattern
+// `Co-authored-by: Name <email>`形式のトレーラー行
+var coAuthorTrailerPattern = regexp.MustCompile(`(?mi)^co-authored-by:\s*(.+?)\s*<([^>]+)>\s*$`)
+
 type gitRepositoryImpl struct {
 	client       gitIF.GitClient
 	configReader gitIF.GitConfigReader
@@ -49,38 +62,137 @@ func (r *gitRepositoryImpl) GetCurrentBranch() (string, error) {
 	return branchName, nil
 }
 
-func (r *gitRepositoryImpl) Commit(message string) error {
+func (r *gitRepositoryImpl) GetRecentAuthors(limit int) ([]object.Signature, error) {
+	repo, err := r.client.PlainOpen(r.repoPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open repository: %w", err)
+	}
+
+	iter, err := repo.Log(&git.LogOptions{Order: git.LogOrderCommitterTime})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit log: %w", err)
+	}
+	defer iter.Close()
+
+	// 自分自身は共同作成者の候補から除外する
+	selfEmail := ""
+	if err := r.configReader.LoadConfig(r.repoPath); err == nil {
+		selfEmail, _ = r.configReader.GetUserEmail()
+	}
+
+	seen := map[string]bool{}
+	if selfEmail != "" {
+		seen[strings.ToLower(selfEmail)] = true
+	}
+	add := func(list []object.Signature, sig object.Signature) []object.Signature {
+		id := strings.ToLower(sig.Email)
+		if id == "" || seen[id] {
+			return list
+		}
+		seen[id] = true
+		return append(list, sig)
+	}
+
+	// Co-authored-byに使われた人とコミット作成者を区別せず、最近関わった順に並べる
+	var authors []object.Signature
+	count := 0
+	err = iter.ForEach(func(c *object.Commit) error {
+		if limit > 0 && count >= limit {
+			return storer.ErrStop
+		}
+		count++
+
+		for _, m := range coAuthorTrailerPattern.FindAllStringSubmatch(c.Message, -1) {
+			authors = add(authors, object.Signature{Name: m[1], Email: m[2], When: c.Author.When})
+		}
+		authors = add(authors, c.Author)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read commit log: %w", err)
+	}
+
+	return authors, nil
+}
+
+func (r *gitRepositoryImpl) GetCommitStats(hash string) (repo.CommitStats, error) {
+	gitRepo, err := r.client.PlainOpen(r.repoPath)
+	if err != nil {
+		return repo.CommitStats{}, fmt.Errorf("failed to open repository: %w", err)
+	}
+
+	commit, err := gitRepo.CommitObject(plumbing.NewHash(hash))
+	if err != nil {
+		return repo.CommitStats{}, fmt.Errorf("failed to get commit: %w", err)
+	}
+
+	fileStats, err := commit.Stats()
+	if err != nil {
+		return repo.CommitStats{}, fmt.Errorf("failed to get commit stats: %w", err)
+	}
+
+	stats := repo.CommitStats{FilesChanged: len(fileStats)}
+	for _, s := range fileStats {
+		stats.Insertions += s.Addition
+		stats.Deletions += s.Deletion
+	}
+	return stats, nil
+}
+
+// GetEditor returns the editor command for commit messages with the same precedence as git:
+// GIT_EDITOR, core.editor, VISUAL, EDITOR and finally vi
+func (r *gitRepositoryImpl) GetEditor() string {
+	if editor := os.Getenv("GIT_EDITOR"); editor != "" {
+		return editor
+	}
+	// 設定を読み込めない場合は環境変数にフォールバックする
+	if err := r.configReader.LoadConfig(r.repoPath); err == nil {
+		if editor, err := r.configReader.GetEditor(); err == nil {
+			return editor
+		}
+	}
+	// gitと同様にダム端末ではVISUALを使用しない
+	if editor := os.Getenv("VISUAL"); editor != "" && os.Getenv("TERM") != "dumb" {
+		return editor
+	}
+	if editor := os.Getenv("EDITOR"); editor != "" {
+		return editor
+	}
+	return defaultEditor
+}
+
+func (r *gitRepositoryImpl) Commit(message string) (string, error) {
 	// Open the repository
 	repo, err := r.client.PlainOpen(r.repoPath)
 	if err != nil {
-		return fmt.Errorf("failed to open repository: %w", err)
+		return "", fmt.Errorf("failed to open repository: %w", err)
 	}
 
 	// Get the worktree
 	worktree, err := repo.Worktree()
 	if err != nil {
-		return fmt.Errorf("failed to get worktree: %w", err)
+		return "", fmt.Errorf("failed to get worktree: %w", err)
 	}
 
 	// Load git configuration and create signature
 	err = r.configReader.LoadConfig(r.repoPath)
 	if err != nil {
-		return fmt.Errorf("failed to load git config: %w", err)
+		return "", fmt.Errorf("failed to load git config: %w", err)
 	}
 
 	signature, err := r.configReader.CreateSignature()
 	if err != nil {
-		return fmt.Errorf("failed to create signature: %w", err)
+		return "", fmt.Errorf("failed to create signature: %w", err)
 	}
 
 	// Create the commit
-	_, err = worktree.Commit(message, &git.CommitOptions{
+	hash, err := worktree.Commit(message, &git.CommitOptions{
 		Author:    signature,
 		Committer: signature,
 	})
 	if err != nil {
-		return fmt.Errorf("failed to commit: %w", err)
+		return "", fmt.Errorf("failed to commit: %w", err)
 	}
 
-	return nil
+	return hash.String(), nil
 }
@@ -5,9 +5,17 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
+	"github.com/cffnpwr/git-cz-go/internal/interface/repo"
 	gitMock "github.com/cffnpwr/git-cz-go/internal/mock/git"
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-billy/v5/util"
+	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+	"github.com/go-git/go-git/v5/storage/memory"
 	"github.com/google/go-cmp/cmp"
 	"go.uber.org/mock/gomock"
 )
@@ -87,6 +95,7 @@ func TestCommit(t *testing.T) {
 		name      string
 		mockSetup func(*gitMock.MockGitClient, *gitMock.MockGitRepository, *gitMock.MockGitWorktree, *gitMock.MockGitConfigReader)
 		message   string
+		wantHash  string
 		wantError error
 	}{
 		{
@@ -99,6 +108,7 @@ func TestCommit(t *testing.T) {
 				mockWorktree.EXPECT().Commit(gomock.Any(), gomock.Any()).Return(plumbing.NewHash("dummy-hash"), nil)
 			},
 			message:   "feat: add new feature",
+			wantHash:  plumbing.NewHash("dummy-hash").String(),
 			wantError: nil,
 		},
 		{
@@ -111,6 +121,7 @@ func TestCommit(t *testing.T) {
 				mockWorktree.EXPECT().Commit(gomock.Any(), gomock.Any()).Return(plumbing.NewHash("dummy-hash"), nil)
 			},
 			message:   "",
+			wantHash:  plumbing.NewHash("dummy-hash").String(),
 			wantError: nil,
 		},
 		{
@@ -168,7 +179,10 @@ func TestCommit(t *testing.T) {
 			tt.mockSetup(mockClient, mockRepo, mockWorktree, mockConfigReader)
 
 			gitRepo := NewGitRepositoryWithClient("/test/path", mockClient, mockConfigReader)
-			err := gitRepo.Commit(tt.message)
+			hash, err := gitRepo.Commit(tt.message)
+			if diff := cmp.Diff(tt.wantHash, hash); diff != "" {
+				t.Errorf("Commit() hash mismatch (-want +got):\n%s", diff)
+			}
 			if err != nil || tt.wantError != nil {
 				if reflect.TypeOf(err) != reflect.TypeOf(tt.wantError) {
 					t.Errorf("Commit() error type mismatch: got %T, want %T", err, tt.wantError)
@@ -177,3 +191,305 @@ func TestCommit(t *testing.T) {
 		})
 	}
 }
+
+// commitSliceIter is a minimal object.CommitIter over a slice of commits
+type commitSliceIter struct {
+	commits []*object.Commit
+	pos     int
+}
+
+func (i *commitSliceIter) Next() (*object.Commit, error) {
+	if i.pos >= len(i.commits) {
+		return nil, storer.ErrStop
+	}
+	c := i.commits[i.pos]
+	i.pos++
+	return c, nil
+}
+
+func (i *commitSliceIter) ForEach(cb func(*object.Commit) error) error {
+	for {
+		c, err := i.Next()
+		if err != nil {
+			return nil
+		}
+		if err := cb(c); err != nil {
+			if errors.Is(err, storer.ErrStop) {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+func (i *commitSliceIter) Close() {}
+
+func createTestCommit(name, email, message string, when time.Time) *object.Commit {
+	return &object.Commit{
+		Author:  object.Signature{Name: name, Email: email, When: when},
+		Message: message,
+	}
+}
+
+func TestGetRecentAuthors(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	commits := []*object.Commit{
+		createTestCommit("Me", "me@example.com", "feat: add\n\nCo-authored-by: Carol <carol@example.com>", now),
+		createTestCommit("Alice", "alice@example.com", "fix: bug", now.Add(-time.Hour)),
+		createTestCommit("Bob", "bob@example.com", "docs: readme", now.Add(-2*time.Hour)),
+		createTestCommit("alice", "ALICE@example.com", "chore: deps", now.Add(-3*time.Hour)),
+	}
+	// 古いコミットのCo-authored-byは新しいコミットの作成者より後ろに並ぶ
+	oldTrailerCommits := []*object.Commit{
+		createTestCommit("Alice", "alice@example.com", "fix: bug", now),
+		createTestCommit("Bob", "bob@example.com", "feat: add\n\nCo-authored-by: Carol <carol@example.com>", now.Add(-time.Hour)),
+	}
+
+	tests := []struct {
+		name      string
+		limit     int
+		mockSetup func(*gitMock.MockGitClient, *gitMock.MockGitRepository, *gitMock.MockGitConfigReader)
+		wantNames []string
+		wantError error
+	}{
+		{
+			name:  "[正常系] 重複と自分を除いて最近関わった順に返す",
+			limit: 0,
+			mockSetup: func(mockClient *gitMock.MockGitClient, mockRepo *gitMock.MockGitRepository, mockConfigReader *gitMock.MockGitConfigReader) {
+				mockClient.EXPECT().PlainOpen("/test/path").Return(mockRepo, nil)
+				mockRepo.EXPECT().Log(gomock.Any()).Return(&commitSliceIter{commits: commits}, nil)
+				mockConfigReader.EXPECT().LoadConfig("/test/path").Return(nil)
+				mockConfigReader.EXPECT().GetUserEmail().Return("me@example.com", nil)
+			},
+			wantNames: []string{"Carol", "Alice", "Bob"},
+		},
+		{
+			name:  "[正常系] 走査するコミット数を制限",
+			limit: 2,
+			mockSetup: func(mockClient *gitMock.MockGitClient, mockRepo *gitMock.MockGitRepository, mockConfigReader *gitMock.MockGitConfigReader) {
+				mockClient.EXPECT().PlainOpen("/test/path").Return(mockRepo, nil)
+				mockRepo.EXPECT().Log(gomock.Any()).Return(&commitSliceIter{commits: commits}, nil)
+				mockConfigReader.EXPECT().LoadConfig("/test/path").Return(nil)
+				mockConfigReader.EXPECT().GetUserEmail().Return("", errors.New("user.email is not configured"))
+			},
+			wantNames: []string{"Carol", "Me", "Alice"},
+		},
+		{
+			name:  "[正常系] 共同作成者とコミット作成者を最近関わった順に並べる",
+			limit: 0,
+			mockSetup: func(mockClient *gitMock.MockGitClient, mockRepo *gitMock.MockGitRepository, mockConfigReader *gitMock.MockGitConfigReader) {
+				mockClient.EXPECT().PlainOpen("/test/path").Return(mockRepo, nil)
+				mockRepo.EXPECT().Log(gomock.Any()).Return(&commitSliceIter{commits: oldTrailerCommits}, nil)
+				mockConfigReader.EXPECT().LoadConfig("/test/path").Return(nil)
+				mockConfigReader.EXPECT().GetUserEmail().Return("me@example.com", nil)
+			},
+			wantNames: []string{"Alice", "Carol", "Bob"},
+		},
+		{
+			name: "[異常系] ログ取得エラー",
+			mockSetup: func(mockClient *gitMock.MockGitClient, mockRepo *gitMock.MockGitRepository, mockConfigReader *gitMock.MockGitConfigReader) {
+				mockClient.EXPECT().PlainOpen("/test/path").Return(mockRepo, nil)
+				mockRepo.EXPECT().Log(gomock.Any()).Return(nil, plumbing.ErrReferenceNotFound)
+			},
+			wantError: fmt.Errorf("failed to get commit log: %w", plumbing.ErrReferenceNotFound),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockClient := gitMock.NewMockGitClient(ctrl)
+			mockRepo := gitMock.NewMockGitRepository(ctrl)
+			mockConfigReader := gitMock.NewMockGitConfigReader(ctrl)
+
+			tt.mockSetup(mockClient, mockRepo, mockConfigReader)
+
+			gitRepo := NewGitRepositoryWithClient("/test/path", mockClient, mockConfigReader)
+			authors, err := gitRepo.GetRecentAuthors(tt.limit)
+			if err != nil || tt.wantError != nil {
+				if reflect.TypeOf(err) != reflect.TypeOf(tt.wantError) {
+					t.Errorf("GetRecentAuthors() error type mismatch: got %T, want %T", err, tt.wantError)
+				}
+				return
+			}
+
+			var names []string
+			for _, a := range authors {
+				names = append(names, a.Name)
+			}
+			if diff := cmp.Diff(tt.wantNames, names); diff != "" {
+				t.Errorf("GetRecentAuthors() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// createTestStatsCommit はメモリ上のリポジトリに、1ファイルを書き換えて1ファイルを追加したコミットを作る
+func createTestStatsCommit(t *testing.T) *object.Commit {
+	t.Helper()
+	r, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	wt, err := r.Worktree()
+	if err != nil {
+		t.Fatalf("Worktree() error = %v", err)
+	}
+
+	commit := func(files map[string]string) plumbing.Hash {
+		for name, content := range files {
+			if err := util.WriteFile(wt.Filesystem, name, []byte(content), 0o644); err != nil {
+				t.Fatalf("WriteFile() error = %v", err)
+			}
+			if _, err := wt.Add(name); err != nil {
+				t.Fatalf("Add() error = %v", err)
+			}
+		}
+		hash, err := wt.Commit("test", &git.CommitOptions{
+			Author: &object.Signature{Name: "Me", Email: "me@example.com", When: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+		})
+		if err != nil {
+			t.Fatalf("Commit() error = %v", err)
+		}
+		return hash
+	}
+
+	commit(map[string]string{"a.txt": "one\ntwo\nthree\n"})
+	hash := commit(map[string]string{
+		"a.txt": "one\n2\nthree\nfour\n",
+		"b.txt": "new\n",
+	})
+	c, err := r.CommitObject(hash)
+	if err != nil {
+		t.Fatalf("CommitObject() error = %v", err)
+	}
+	return c
+}
+
+func TestGetCommitStats(t *testing.T) {
+	tests := []struct {
+		name      string
+		mockSetup func(*gitMock.MockGitClient, *gitMock.MockGitRepository)
+		want      repo.CommitStats
+		wantError error
+	}{
+		{
+			name: "[正常系] 変更したファイル数と追加・削除した行数を集計",
+			mockSetup: func(mockClient *gitMock.MockGitClient, mockRepo *gitMock.MockGitRepository) {
+				mockClient.EXPECT().PlainOpen("/test/path").Return(mockRepo, nil)
+				mockRepo.EXPECT().CommitObject(plumbing.NewHash("0123456")).Return(createTestStatsCommit(t), nil)
+			},
+			want: repo.CommitStats{FilesChanged: 2, Insertions: 3, Deletions: 1},
+		},
+		{
+			name: "[異常系] 無効なリポジトリパスでのエラー",
+			mockSetup: func(mockClient *gitMock.MockGitClient, mockRepo *gitMock.MockGitRepository) {
+				mockClient.EXPECT().PlainOpen("/test/path").Return(nil, fmt.Errorf("failed to open repository"))
+			},
+			wantError: fmt.Errorf("failed to open repository: %w", fmt.Errorf("")),
+		},
+		{
+			name: "[異常系] コミットが存在しない",
+			mockSetup: func(mockClient *gitMock.MockGitClient, mockRepo *gitMock.MockGitRepository) {
+				mockClient.EXPECT().PlainOpen("/test/path").Return(mockRepo, nil)
+				mockRepo.EXPECT().CommitObject(plumbing.NewHash("0123456")).Return(nil, plumbing.ErrObjectNotFound)
+			},
+			wantError: fmt.Errorf("failed to get commit: %w", plumbing.ErrObjectNotFound),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockClient := gitMock.NewMockGitClient(ctrl)
+			mockRepo := gitMock.NewMockGitRepository(ctrl)
+			mockConfigReader := gitMock.NewMockGitConfigReader(ctrl)
+
+			tt.mockSetup(mockClient, mockRepo)
+
+			gitRepo := NewGitRepositoryWithClient("/test/path", mockClient, mockConfigReader)
+			stats, err := gitRepo.GetCommitStats("0123456")
+			if reflect.TypeOf(err) != reflect.TypeOf(tt.wantError) {
+				t.Errorf("GetCommitStats() error type mismatch: got %T, want %T", err, tt.wantError)
+			}
+			if diff := cmp.Diff(tt.want, stats); diff != "" {
+				t.Errorf("GetCommitStats() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGetEditor(t *testing.T) {
+	tests := []struct {
+		name      string
+		env       map[string]string
+		mockSetup func(*gitMock.MockGitConfigReader)
+		want      string
+	}{
+		{
+			name: "[正常系] GIT_EDITORが最優先",
+			env:  map[string]string{"GIT_EDITOR": "nano", "VISUAL": "code --wait", "EDITOR": "vim"},
+			want: "nano",
+		},
+		{
+			name: "[正常系] core.editorがVISUALより優先",
+			env:  map[string]string{"VISUAL": "code --wait", "EDITOR": "vim"},
+			mockSetup: func(mockConfigReader *gitMock.MockGitConfigReader) {
+				mockConfigReader.EXPECT().LoadConfig("/test/path").Return(nil)
+				mockConfigReader.EXPECT().GetEditor().Return("emacs", nil)
+			},
+			want: "emacs",
+		},
+		{
+			name: "[正常系] VISUALがEDITORより優先",
+			env:  map[string]string{"VISUAL": "code --wait", "EDITOR": "vim"},
+			mockSetup: func(mockConfigReader *gitMock.MockGitConfigReader) {
+				mockConfigReader.EXPECT().LoadConfig("/test/path").Return(nil)
+				mockConfigReader.EXPECT().GetEditor().Return("", errors.New("core.editor is not configured"))
+			},
+			want: "code --wait",
+		},
+		{
+			name: "[正常系] ダム端末ではVISUALを使用しない",
+			env:  map[string]string{"VISUAL": "code --wait", "EDITOR": "vim", "TERM": "dumb"},
+			mockSetup: func(mockConfigReader *gitMock.MockGitConfigReader) {
+				mockConfigReader.EXPECT().LoadConfig("/test/path").Return(nil)
+				mockConfigReader.EXPECT().GetEditor().Return("", errors.New("core.editor is not configured"))
+			},
+			want: "vim",
+		},
+		{
+			name: "[正常系] 何も設定されていない場合はvi",
+			mockSetup: func(mockConfigReader *gitMock.MockGitConfigReader) {
+				mockConfigReader.EXPECT().LoadConfig("/test/path").Return(errors.New("failed to load"))
+			},
+			want: "vi",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			for _, k := range []string{"GIT_EDITOR", "VISUAL", "EDITOR", "TERM"} {
+				t.Setenv(k, tt.env[k])
+			}
+
+			mockClient := gitMock.NewMockGitClient(ctrl)
+			mockConfigReader := gitMock.NewMockGitConfigReader(ctrl)
+			if tt.mockSetup != nil {
+				tt.mockSetup(mockConfigReader)
+			}
+			gitRepo := NewGitRepositoryWithClient("/test/path", mockClient, mockConfigReader)
+
+			if got := gitRepo.GetEditor(); got != tt.want {
+				t.Errorf("GetEditor() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	gitIF "github.com/cffnpwr/git-cz-go/internal/interface/git"
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
 )
 
 type GitRepository struct {
@@ -17,3 +18,11 @@ func (r *GitRepository) Head() (*plumbing.Reference, error) {
 func (r *GitRepository) Worktree() (gitIF.GitWorktree, error) {
 	return r.repo.Worktree()
 }
+
+func (r *GitRepository) Log(o *git.LogOptions) (object.CommitIter, error) {
+	return r.repo.Log(o)
+}
+
+func (r *GitRepository) CommitObject(h plumbing.Hash) (*object.Commit, error) {
+	return r.repo.CommitObject(h)
+}
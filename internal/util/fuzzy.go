@@ -0,0 +1,46 @@
+package util
+
+import (
+	"unicode"
+)
+
+// Scores added for each matched character of FuzzyMatch
+const (
+	fuzzyMatchScore       = 1
+	fuzzyConsecutiveBonus = 5
+	fuzzyWordStartBonus   = 8
+)
+
+// FuzzyMatch reports whether every character of pattern appears in s in order, ignoring case.
+// It returns a score that is higher for consecutive matches and matches at the start of words,
+// and the indexes of the matched runes in s.
+func FuzzyMatch(pattern, s string) (int, []int, bool) {
+	p := []rune(pattern)
+	if len(p) == 0 {
+		return 0, nil, true
+	}
+
+	score := 0
+	var positions []int
+	prev := ' '
+	i := 0
+	for pos, r := range []rune(s) {
+		if i < len(p) && unicode.ToLower(r) == unicode.ToLower(p[i]) {
+			score += fuzzyMatchScore
+			if len(positions) > 0 && positions[len(positions)-1] == pos-1 {
+				score += fuzzyConsecutiveBonus
+			}
+			if !unicode.IsLetter(prev) && !unicode.IsDigit(prev) {
+				score += fuzzyWordStartBonus
+			}
+			positions = append(positions, pos)
+			i++
+		}
+		prev = r
+	}
+	if i < len(p) {
+		return 0, nil, false
+	}
+	// 先頭に近い位置から一致する方を優先する
+	return score - positions[0], positions, true
+}
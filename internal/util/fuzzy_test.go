@@ -0,0 +1,71 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFuzzyMatch(t *testing.T) {
+	tests := []struct {
+		name          string
+		pattern       string
+		s             string
+		wantPositions []int
+		wantOK        bool
+	}{
+		{
+			name:   "[正常系] 空のパターンはすべてに一致",
+			s:      "feat",
+			wantOK: true,
+		},
+		{
+			name:          "[正常系] 順に含まれる文字に一致",
+			pattern:       "rvt",
+			s:             "revert",
+			wantPositions: []int{0, 2, 5},
+			wantOK:        true,
+		},
+		{
+			name:          "[正常系] 大文字と小文字を区別しない",
+			pattern:       "FiX",
+			s:             "fix: バグ修正",
+			wantPositions: []int{0, 1, 2},
+			wantOK:        true,
+		},
+		{
+			name:          "[正常系] 全角文字の位置はルーン単位",
+			pattern:       "修正",
+			s:             "fix: バグ修正",
+			wantPositions: []int{7, 8},
+			wantOK:        true,
+		},
+		{
+			name:    "[異常系] 順序が異なる",
+			pattern: "tfe",
+			s:       "feat",
+			wantOK:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, positions, ok := FuzzyMatch(tt.pattern, tt.s)
+			if ok != tt.wantOK {
+				t.Fatalf("FuzzyMatch() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if diff := cmp.Diff(tt.wantPositions, positions); diff != "" {
+				t.Errorf("FuzzyMatch() positions mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFuzzyMatch_Score(t *testing.T) {
+	// 連続した一致と単語の先頭での一致を優先する
+	consecutive, _, _ := FuzzyMatch("fix", "fix: bug")
+	scattered, _, _ := FuzzyMatch("fix", "feat: index")
+	if consecutive <= scattered {
+		t.Errorf("score of consecutive match %d should be higher than %d", consecutive, scattered)
+	}
+}
@@ -0,0 +1,147 @@
+package util
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// BulletPattern matches the marker of a list item such as `- `, `* ` or `1. ` including its indent
+var BulletPattern = regexp.MustCompile(`^(\s*)([-*+]|\d+[.)])\s+`)
+
+// CodeFencePattern matches the line opening or closing a fenced code block with ``` or ~~~
+var CodeFencePattern = regexp.MustCompile("^\\s*(```|~~~)")
+
+// closingPunctuation は行頭に置かない約物
+const closingPunctuation = "、。，．・：；？！）」』】〉》〕｝ー…"
+
+type wrapToken struct {
+	text        string
+	spaceBefore bool
+}
+
+// Wrap hard wraps text at width display columns.
+// East Asian wide characters count as two columns and may break anywhere except before closing punctuation.
+// List items keep a hanging indent and paragraphs indented by less than four spaces keep their indent,
+// while code blocks and lines indented by four spaces or a tab are left as they are.
+func Wrap(text string, width int) string {
+	var out []string
+	lines := strings.Split(text, "\n")
+
+	inCode := false
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		switch {
+		case CodeFencePattern.MatchString(line):
+			inCode = !inCode
+			out = append(out, line)
+		case inCode, strings.TrimSpace(line) == "", isIndented(line):
+			out = append(out, line)
+		default:
+			// 箇条書きの項目は行頭の記号の幅だけ続く行を字下げする
+			// それ以外の字下げされた段落は続く行も同じだけ字下げする
+			first, rest := "", ""
+			if m := BulletPattern.FindString(line); m != "" {
+				first, rest = m, strings.Repeat(" ", runewidth.StringWidth(m))
+				line = line[len(m):]
+			} else if trimmed := strings.TrimLeft(line, " "); trimmed != line {
+				first = line[:len(line)-len(trimmed)]
+				rest = first
+				line = trimmed
+			}
+
+			// 空行、別の項目、コードブロックが現れるまでを1つの段落として連結する
+			for i+1 < len(lines) && isContinuation(lines[i+1]) {
+				i++
+				line = joinLines(line, strings.TrimSpace(lines[i]))
+			}
+			out = append(out, wrapTokens(tokenize(line), width, first, rest)...)
+		}
+	}
+	return strings.Join(out, "\n")
+}
+
+func isIndented(line string) bool {
+	return strings.HasPrefix(line, "    ") || strings.HasPrefix(line, "\t")
+}
+
+func isContinuation(line string) bool {
+	return strings.TrimSpace(line) != "" &&
+		!BulletPattern.MatchString(line) &&
+		!CodeFencePattern.MatchString(line) &&
+		!isIndented(line)
+}
+
+// joinLines は全角文字同士の改行は空白を挟まずに連結する
+func joinLines(a, b string) string {
+	ar, br := []rune(a), []rune(b)
+	if len(ar) > 0 && len(br) > 0 && isWide(ar[len(ar)-1]) && isWide(br[0]) {
+		return a + b
+	}
+	return a + " " + b
+}
+
+func isWide(r rune) bool {
+	return runewidth.RuneWidth(r) > 1
+}
+
+// tokenize は改行可能な位置で文字列を分割する
+// 半角の単語は空白で、全角文字は1文字ずつ分割する
+func tokenize(s string) []wrapToken {
+	var tokens []wrapToken
+	var word strings.Builder
+	space := false
+	flush := func() {
+		if word.Len() > 0 {
+			tokens = append(tokens, wrapToken{text: word.String(), spaceBefore: space})
+			word.Reset()
+			space = false
+		}
+	}
+
+	for _, r := range s {
+		switch {
+		case unicode.IsSpace(r):
+			flush()
+			space = len(tokens) > 0
+		case strings.ContainsRune(closingPunctuation, r) && word.Len() > 0:
+			word.WriteRune(r)
+		case strings.ContainsRune(closingPunctuation, r) && len(tokens) > 0 && !space:
+			tokens[len(tokens)-1].text += string(r)
+		case isWide(r):
+			flush()
+			tokens = append(tokens, wrapToken{text: string(r), spaceBefore: space})
+			space = false
+		default:
+			word.WriteRune(r)
+		}
+	}
+	flush()
+	return tokens
+}
+
+func wrapTokens(tokens []wrapToken, width int, firstIndent, restIndent string) []string {
+	var lines []string
+	line := firstIndent
+	lineWidth := runewidth.StringWidth(firstIndent)
+	empty := true
+
+	for _, t := range tokens {
+		w := runewidth.StringWidth(t.text)
+		sep := ""
+		if t.spaceBefore && !empty {
+			sep = " "
+		}
+		// 幅を超える単語（URLなど）は分割せずにそのまま置く
+		if !empty && lineWidth+len(sep)+w > width {
+			lines = append(lines, line)
+			line, lineWidth, sep = restIndent, runewidth.StringWidth(restIndent), ""
+		}
+		line += sep + t.text
+		lineWidth += len(sep) + w
+		empty = false
+	}
+	return append(lines, line)
+}
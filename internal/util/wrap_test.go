@@ -0,0 +1,98 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWrap(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		width int
+		want  string
+	}{
+		{
+			name:  "[正常系] 幅に収まる場合はそのまま",
+			text:  "short line",
+			width: 20,
+			want:  "short line",
+		},
+		{
+			name:  "[正常系] 単語の区切りで折り返す",
+			text:  "the quick brown fox jumps over the lazy dog",
+			width: 15,
+			want:  "the quick brown\nfox jumps over\nthe lazy dog",
+		},
+		{
+			name:  "[正常系] 段落内の改行を連結して折り返す",
+			text:  "one two\nthree four five\n\nsix",
+			width: 12,
+			want:  "one two\nthree four\nfive\n\nsix",
+		},
+		{
+			name:  "[正常系] 全角文字は2桁として数える",
+			text:  "日本語のコミットメッセージ",
+			width: 10,
+			want:  "日本語のコ\nミットメッ\nセージ",
+		},
+		{
+			name:  "[正常系] 句読点は行頭に置かない",
+			text:  "あいうえ。かきくけこ",
+			width: 8,
+			want:  "あいう\nえ。かき\nくけこ",
+		},
+		{
+			name:  "[正常系] 全角文字の行の連結には空白を入れない",
+			text:  "変更を\n追加",
+			width: 20,
+			want:  "変更を追加",
+		},
+		{
+			name:  "[正常系] 箇条書きはぶら下げて字下げする",
+			text:  "- first item is long\n  and continues\n- second",
+			width: 12,
+			want:  "- first item\n  is long\n  and\n  continues\n- second",
+		},
+		{
+			name:  "[正常系] 番号付きの箇条書き",
+			text:  "1. alpha beta gamma",
+			width: 10,
+			want:  "1. alpha\n   beta\n   gamma",
+		},
+		{
+			name:  "[正常系] コードブロックは折り返さない",
+			text:  "```\nsome very long code line here\n```\nand text after it",
+			width: 10,
+			want:  "```\nsome very long code line here\n```\nand text\nafter it",
+		},
+		{
+			name:  "[正常系] 字下げされた行は折り返さない",
+			text:  "    indented code line here",
+			width: 10,
+			want:  "    indented code line here",
+		},
+		{
+			name:  "[正常系] 浅く字下げされた段落は字下げを保って折り返す",
+			text:  "  indented text here\n  and more",
+			width: 10,
+			want:  "  indented\n  text\n  here and\n  more",
+		},
+		{
+			name:  "[正常系] 幅を超える単語は分割しない",
+			text:  "see https://example.com/very/long/url",
+			width: 10,
+			want:  "see\nhttps://example.com/very/long/url",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Wrap(tt.text, tt.width)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Wrap() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,273 @@
+package choice
+
+import (
+	"errors"
+
+	"github.com/cffnpwr/git-cz-go/pkg/theme"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	defaultPrompt = "Choose"
+)
+
+// styles は renderer から作るボタンのスタイル。色はテーマから表示時に設定する
+type styles struct {
+	button   lipgloss.Style
+	selected lipgloss.Style
+}
+
+func newStyles(r *lipgloss.Renderer) styles {
+	button := r.NewStyle().Padding(0, 2).Margin(1, 1)
+	return styles{
+		button:   button,
+		selected: button.Bold(true),
+	}
+}
+
+// Layout is the direction the options are arranged in
+type Layout int
+
+const (
+	LayoutHorizontal Layout = iota
+	LayoutVertical
+)
+
+type KeyMap struct {
+	Next   key.Binding
+	Prev   key.Binding
+	Select key.Binding
+	Quit   key.Binding
+}
+
+var DefaultKeyMap = KeyMap{
+	Next: key.NewBinding(
+		key.WithKeys("tab", "l", "right", "j", "down"),
+		key.WithHelp("→/l", "next option"),
+	),
+	Prev: key.NewBinding(
+		key.WithKeys("shift+tab", "h", "left", "k", "up"),
+		key.WithHelp("←/h", "previous option"),
+	),
+	Select: key.NewBinding(
+		key.WithKeys("enter", " "),
+		key.WithHelp("enter/space", "confirm selection"),
+	),
+	Quit: key.NewBinding(
+		key.WithKeys("ctrl+c", "esc"),
+		key.WithHelp("ctrl+c/esc", "quit"),
+	),
+}
+
+// Option is a labelled button of the choice
+type Option struct {
+	Label string      // Text shown on the button
+	Key   key.Binding // Hotkey choosing the option at once, optional
+}
+
+type Model struct {
+	Prompt string // Question prompt
+
+	options   []Option // Options in display order
+	cursor    int      // Index of the highlighted option
+	confirmed bool     // Confirmation status
+	layout    Layout   // Direction the options are arranged in
+	width     int      // Width available to the view, 0 means no limit
+
+	keyMap KeyMap      // Key map
+	theme  theme.Theme // Colors of the buttons
+	styles styles      // Styles built from the renderer
+}
+
+func New(options ...Option) (Model, error) {
+	if len(options) == 0 {
+		return Model{}, errors.New("no options, must have at least one option")
+	}
+	return Model{
+		Prompt:  defaultPrompt,
+		options: options,
+		layout:  LayoutHorizontal,
+		keyMap:  DefaultKeyMap,
+		theme:   theme.Default,
+		styles:  newStyles(lipgloss.DefaultRenderer()),
+	}, nil
+}
+
+// SetDefault highlights the option at the index, ignoring an index out of range
+func (m Model) SetDefault(index int) Model {
+	if index >= 0 && index < len(m.options) {
+		m.cursor = index
+	}
+	return m
+}
+
+func (m Model) SetLayout(l Layout) Model {
+	m.layout = l
+	return m
+}
+
+// SetWidth sets the width available to the view.
+// Options arranged horizontally are stacked vertically when they do not fit in it. 0 means no limit.
+func (m Model) SetWidth(w int) Model {
+	m.width = w
+	return m
+}
+
+func (m Model) SetKeyMap(km KeyMap) Model {
+	m.keyMap = km
+	return m
+}
+
+// SetTheme sets the colors of the buttons
+func (m Model) SetTheme(t theme.Theme) Model {
+	m.theme = t
+	return m
+}
+
+// SetRenderer sets the renderer the styles are built from, which detects the colors the output supports
+func (m Model) SetRenderer(r *lipgloss.Renderer) Model {
+	m.styles = newStyles(r)
+	return m
+}
+
+// Reset clears the confirmation and keeps the highlighted option so it can be confirmed again
+func (m Model) Reset() Model {
+	m.confirmed = false
+	return m
+}
+
+// GetIndex returns the index of the highlighted option
+func (m Model) GetIndex() int {
+	return m.cursor
+}
+
+// GetOption returns the highlighted option
+func (m Model) GetOption() Option {
+	return m.options[m.cursor]
+}
+
+func (m Model) IsConfirmed() bool {
+	return m.confirmed
+}
+
+// ShortHelp returns the bindings shown in the short help, implementing help.KeyMap
+func (m Model) ShortHelp() []key.Binding {
+	bindings := []key.Binding{m.keyMap.Prev, m.keyMap.Next, m.keyMap.Select}
+	for _, o := range m.options {
+		bindings = append(bindings, o.Key)
+	}
+	return append(bindings, m.keyMap.Quit)
+}
+
+// FullHelp returns the bindings shown in the full help, implementing help.KeyMap
+func (m Model) FullHelp() [][]key.Binding {
+	options := make([]key.Binding, len(m.options))
+	for i, o := range m.options {
+		options[i] = o.Key
+	}
+	return [][]key.Binding{{m.keyMap.Prev, m.keyMap.Next}, options, {m.keyMap.Select, m.keyMap.Quit}}
+}
+
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.MouseMsg:
+		if m.confirmed {
+			return m, nil
+		}
+		if i, ok := m.optionAt(msg); ok {
+			m.cursor = i
+			m.confirmed = true
+		}
+	case tea.KeyMsg:
+		km := m.keyMap
+		if key.Matches(msg, km.Quit) {
+			return m, tea.Quit
+		}
+		if m.confirmed {
+			return m, nil
+		}
+		// 選択肢のキーは移動のキーより優先する
+		for i, o := range m.options {
+			if key.Matches(msg, o.Key) {
+				m.cursor = i
+				m.confirmed = true
+				return m, nil
+			}
+		}
+		switch {
+		case key.Matches(msg, km.Next):
+			m.cursor = (m.cursor + 1) % len(m.options)
+		case key.Matches(msg, km.Prev):
+			m.cursor = (m.cursor - 1 + len(m.options)) % len(m.options)
+		case key.Matches(msg, km.Select):
+			m.confirmed = true
+		}
+	}
+
+	return m, nil
+}
+
+// buttons は各選択肢のボタンを表示順に返す
+func (m Model) buttons() []string {
+	idle := m.styles.button.Background(m.theme.ButtonIdleBg)
+	// 選択の印は幅が変わらないように左右の余白に置く
+	marks := m.theme.ButtonMarks
+	selected := m.styles.selected.Foreground(m.theme.ButtonFg).Background(m.theme.ButtonBg).
+		PaddingLeft(m.styles.button.GetPaddingLeft() - lipgloss.Width(marks[0])).
+		PaddingRight(m.styles.button.GetPaddingRight() - lipgloss.Width(marks[1]))
+
+	buttons := make([]string, len(m.options))
+	for i, o := range m.options {
+		if i == m.cursor {
+			buttons[i] = selected.Render(marks[0] + o.Label + marks[1])
+		} else {
+			buttons[i] = idle.Render(o.Label)
+		}
+	}
+	return buttons
+}
+
+// optionAt はクリックされた選択肢を返す。座標はViewの左上を原点とし、ボタンの外側の余白は含めない
+func (m Model) optionAt(msg tea.MouseMsg) (int, bool) {
+	if msg.Action != tea.MouseActionPress || msg.Button != tea.MouseButtonLeft {
+		return 0, false
+	}
+
+	x, y := 0, lipgloss.Height(m.Prompt)
+	vertical := m.currentLayout() == LayoutVertical
+	for i, b := range m.buttons() {
+		w, h := lipgloss.Width(b), lipgloss.Height(b)
+		if msg.X >= x+m.styles.button.GetMarginLeft() && msg.X < x+w-m.styles.button.GetMarginRight() &&
+			msg.Y >= y+m.styles.button.GetMarginTop() && msg.Y < y+h-m.styles.button.GetMarginBottom() {
+			return i, true
+		}
+		if vertical {
+			y += h
+		} else {
+			x += w
+		}
+	}
+	return 0, false
+}
+
+// currentLayout は横並びのボタンが幅に収まらない場合に縦並びを返す
+func (m Model) currentLayout() Layout {
+	if m.layout == LayoutHorizontal && m.width > 0 &&
+		lipgloss.Width(lipgloss.JoinHorizontal(lipgloss.Top, m.buttons()...)) > m.width {
+		return LayoutVertical
+	}
+	return m.layout
+}
+
+func (m Model) View() string {
+	if m.currentLayout() == LayoutVertical {
+		return m.Prompt + "\n" + lipgloss.JoinVertical(lipgloss.Left, m.buttons()...)
+	}
+	return m.Prompt + "\n" + lipgloss.JoinHorizontal(lipgloss.Top, m.buttons()...)
+}
@@ -0,0 +1,372 @@
+package choice
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cffnpwr/git-cz-go/pkg/theme"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/exp/teatest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/muesli/termenv"
+)
+
+// ModelWrapper wraps Model to implement tea.Model interface properly
+type ModelWrapper struct {
+	Model
+}
+
+func (w ModelWrapper) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	model, cmd := w.Model.Update(msg)
+	return ModelWrapper{model}, cmd
+}
+
+func createTestOptions() []Option {
+	return []Option{
+		{Label: "Yes", Key: key.NewBinding(key.WithKeys("y"))},
+		{Label: "Edit", Key: key.NewBinding(key.WithKeys("e"))},
+		{Label: "Back"},
+		{Label: "Cancel", Key: key.NewBinding(key.WithKeys("n"))},
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name      string
+		options   []Option
+		wantModel Model
+		wantError error
+	}{
+		{
+			name:    "[正常系] モデル初期化",
+			options: createTestOptions(),
+			wantModel: Model{
+				Prompt: defaultPrompt,
+			},
+		},
+		{
+			name:      "[異常系] 選択肢が無い",
+			options:   nil,
+			wantError: errors.New("no options, must have at least one option"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model, err := New(tt.options...)
+
+			if err != nil || tt.wantError != nil {
+				if reflect.TypeOf(err) != reflect.TypeOf(tt.wantError) {
+					t.Errorf("New() error type mismatch: got %T, want %T", err, tt.wantError)
+				}
+				return
+			}
+
+			if diff := cmp.Diff(tt.wantModel, model, cmpopts.IgnoreUnexported(Model{})); diff != "" {
+				t.Errorf("New() mismatch (-want +got):\n%s", diff)
+			}
+			if model.GetIndex() != 0 || model.IsConfirmed() {
+				t.Errorf("New() index = %d, confirmed = %t, want 0, false", model.GetIndex(), model.IsConfirmed())
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	tests := []struct {
+		name          string
+		defaultIndex  int
+		keyInputs     []tea.KeyMsg
+		wantIndex     int
+		wantConfirmed bool
+	}{
+		{
+			name:      "[正常系] 次の選択肢へ移動",
+			keyInputs: []tea.KeyMsg{{Type: tea.KeyTab}, {Type: tea.KeyRight}},
+			wantIndex: 2,
+		},
+		{
+			name:      "[正常系] 先頭から前へ移動すると末尾へ回り込む",
+			keyInputs: []tea.KeyMsg{{Type: tea.KeyShiftTab}},
+			wantIndex: 3,
+		},
+		{
+			name:         "[正常系] 末尾から次へ移動すると先頭へ回り込む",
+			defaultIndex: 3,
+			keyInputs:    []tea.KeyMsg{{Type: tea.KeyDown}},
+			wantIndex:    0,
+		},
+		{
+			name:          "[正常系] 既定の選択肢を確定",
+			defaultIndex:  3,
+			keyInputs:     []tea.KeyMsg{{Type: tea.KeyEnter}},
+			wantIndex:     3,
+			wantConfirmed: true,
+		},
+		{
+			name:          "[正常系] 選択肢のキーで即座に確定",
+			keyInputs:     []tea.KeyMsg{{Type: tea.KeyRunes, Runes: []rune("e")}},
+			wantIndex:     1,
+			wantConfirmed: true,
+		},
+		{
+			name:          "[正常系] 確定後の移動は無視",
+			keyInputs:     []tea.KeyMsg{{Type: tea.KeyRunes, Runes: []rune("n")}, {Type: tea.KeyTab}},
+			wantIndex:     3,
+			wantConfirmed: true,
+		},
+		{
+			name:         "[異常系] 範囲外の既定値は無視",
+			defaultIndex: 4,
+			wantIndex:    0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model, err := New(createTestOptions()...)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			model = model.SetDefault(tt.defaultIndex)
+			for _, msg := range tt.keyInputs {
+				model, _ = model.Update(msg)
+			}
+
+			if model.GetIndex() != tt.wantIndex {
+				t.Errorf("GetIndex() = %d, want %d", model.GetIndex(), tt.wantIndex)
+			}
+			if model.GetOption().Label != createTestOptions()[tt.wantIndex].Label {
+				t.Errorf("GetOption() = %q, want %q", model.GetOption().Label, createTestOptions()[tt.wantIndex].Label)
+			}
+			if model.IsConfirmed() != tt.wantConfirmed {
+				t.Errorf("IsConfirmed() = %t, want %t", model.IsConfirmed(), tt.wantConfirmed)
+			}
+		})
+	}
+}
+
+func TestQuit(t *testing.T) {
+	tests := []struct {
+		name     string
+		keyInput tea.KeyMsg
+	}{
+		{
+			name:     "[正常系] エスケープキーで終了",
+			keyInput: tea.KeyMsg{Type: tea.KeyEsc},
+		},
+		{
+			name:     "[正常系] Ctrl+Cで終了",
+			keyInput: tea.KeyMsg{Type: tea.KeyCtrlC},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model, err := New(createTestOptions()...)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			tm := teatest.NewTestModel(t, ModelWrapper{model}, teatest.WithInitialTermSize(80, 24))
+			tm.Send(tt.keyInput)
+			tm.WaitFinished(t, teatest.WithFinalTimeout(time.Second))
+		})
+	}
+}
+
+func TestMouse(t *testing.T) {
+	press := func(x, y int) tea.MouseMsg {
+		return tea.MouseMsg{X: x, Y: y, Action: tea.MouseActionPress, Button: tea.MouseButtonLeft}
+	}
+
+	// 各ボタンは左右に1の余白と2の内側の余白を持つ
+	tests := []struct {
+		name          string
+		layout        Layout
+		msg           tea.MouseMsg
+		wantIndex     int
+		wantConfirmed bool
+	}{
+		{
+			name:          "[正常系] 横並びで3番目をクリック",
+			layout:        LayoutHorizontal,
+			msg:           press(9+10+3, 2),
+			wantIndex:     2,
+			wantConfirmed: true,
+		},
+		{
+			name:          "[正常系] 縦並びで2番目をクリック",
+			layout:        LayoutVertical,
+			msg:           press(2, 5),
+			wantIndex:     1,
+			wantConfirmed: true,
+		},
+		{
+			name:   "[異常系] ボタンの間の余白をクリック",
+			layout: LayoutVertical,
+			msg:    press(2, 3),
+		},
+		{
+			name:   "[異常系] クリック以外は無視",
+			layout: LayoutHorizontal,
+			msg:    tea.MouseMsg{X: 3, Y: 2, Action: tea.MouseActionRelease, Button: tea.MouseButtonLeft},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model, err := New(createTestOptions()...)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			model, _ = model.SetLayout(tt.layout).Update(tt.msg)
+
+			if model.GetIndex() != tt.wantIndex {
+				t.Errorf("GetIndex() = %d, want %d\n%s", model.GetIndex(), tt.wantIndex, model.View())
+			}
+			if model.IsConfirmed() != tt.wantConfirmed {
+				t.Errorf("IsConfirmed() = %t, want %t", model.IsConfirmed(), tt.wantConfirmed)
+			}
+		})
+	}
+}
+
+func TestView(t *testing.T) {
+	tests := []struct {
+		name      string
+		layout    Layout
+		width     int
+		wantLines int
+	}{
+		{
+			name:      "[正常系] 横並び",
+			layout:    LayoutHorizontal,
+			wantLines: 4,
+		},
+		{
+			name:      "[正常系] 縦並び",
+			layout:    LayoutVertical,
+			wantLines: 13,
+		},
+		{
+			name:      "[正常系] 幅に収まる横並び",
+			layout:    LayoutHorizontal,
+			width:     41,
+			wantLines: 4,
+		},
+		{
+			name:      "[正常系] 幅に収まらない横並びは縦並びで表示",
+			layout:    LayoutHorizontal,
+			width:     40,
+			wantLines: 13,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model, err := New(createTestOptions()...)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			view := model.SetLayout(tt.layout).SetWidth(tt.width).View()
+
+			for _, o := range createTestOptions() {
+				if !strings.Contains(view, o.Label) {
+					t.Errorf("View() = %q, want to contain %q", view, o.Label)
+				}
+			}
+			if got := strings.Count(view, "\n") + 1; got != tt.wantLines {
+				t.Errorf("View() has %d lines, want %d", got, tt.wantLines)
+			}
+		})
+	}
+}
+
+func TestSetTheme(t *testing.T) {
+	model, err := New(createTestOptions()...)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	view := model.View()
+	noneView := model.SetTheme(theme.None).View()
+
+	// 色が無くても選択中のボタンが分かり、幅は変わらない
+	if !strings.Contains(noneView, "[Yes]") {
+		t.Errorf("View() = %q, want to contain %q", noneView, "[Yes]")
+	}
+	if got, want := lipgloss.Width(noneView), lipgloss.Width(view); got != want {
+		t.Errorf("View() width = %d, want %d", got, want)
+	}
+}
+
+func TestHelp(t *testing.T) {
+	model, err := New(createTestOptions()...)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	// キーの無い選択肢は表示されないキーバインドになる
+	var keys [][]string
+	for _, b := range model.ShortHelp() {
+		keys = append(keys, b.Keys())
+	}
+	want := [][]string{
+		DefaultKeyMap.Prev.Keys(),
+		DefaultKeyMap.Next.Keys(),
+		DefaultKeyMap.Select.Keys(),
+		{"y"},
+		{"e"},
+		nil,
+		{"n"},
+		DefaultKeyMap.Quit.Keys(),
+	}
+	if diff := cmp.Diff(want, keys); diff != "" {
+		t.Errorf("ShortHelp() mismatch (-want +got):\n%s", diff)
+	}
+	if got := len(model.FullHelp()); got != 3 {
+		t.Errorf("len(FullHelp()) = %d, want 3", got)
+	}
+}
+
+func TestSetRenderer(t *testing.T) {
+	tests := []struct {
+		name      string
+		profile   termenv.Profile
+		wantColor bool
+	}{
+		{
+			name:      "[正常系] 色を扱える出力の renderer では色を付ける",
+			profile:   termenv.TrueColor,
+			wantColor: true,
+		},
+		{
+			name:      "[正常系] 色を扱えない出力の renderer では色を付けない",
+			profile:   termenv.Ascii,
+			wantColor: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model, err := New(createTestOptions()...)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			r := lipgloss.NewRenderer(io.Discard)
+			r.SetColorProfile(tt.profile)
+			view := model.SetTheme(theme.Dark).SetRenderer(r).View()
+			// 24bit の前景色か背景色の指定が含まれるか
+			if got := strings.Contains(view, "8;2;"); got != tt.wantColor {
+				t.Errorf("View() colored = %v, want %v: %q", got, tt.wantColor, view)
+			}
+		})
+	}
+}
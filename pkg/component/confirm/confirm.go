@@ -1,6 +1,8 @@
 package confirm
 
 import (
+	"github.com/cffnpwr/git-cz-go/pkg/component/choice"
+	"github.com/cffnpwr/git-cz-go/pkg/theme"
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -8,16 +10,9 @@ import (
 
 const (
 	defaultPrompt = "Confirm"
-)
-
-var (
-	fgColor = lipgloss.Color("#bb9af7")
-	bgColor = lipgloss.Color("#1e1e2e")
-
-	border = lipgloss.NormalBorder()
 
-	style         = lipgloss.NewStyle().Background(bgColor).Padding(0, 2).Margin(1, 1)
-	selectedStyle = style.Foreground(lipgloss.Color("#ffffff")).Background(fgColor).Bold(true)
+	defaultAffirmativeLabel = "Yes"
+	defaultNegativeLabel    = "No"
 )
 
 type KeyMap struct {
@@ -51,21 +46,32 @@ var DefaultKeyMap = KeyMap{
 	),
 }
 
+// Model is a Yes/No choice kept for the components asking a binary question
 type Model struct {
 	Prompt string // Question prompt
 
 	value     bool // Selected value
 	confirmed bool // Confirmation status
 
-	keyMap KeyMap // Key map
+	affirmativeLabel string // Label of the Yes button
+	negativeLabel    string // Label of the No button
+
+	keyMap   KeyMap             // Key map
+	theme    theme.Theme        // Colors of the buttons
+	renderer *lipgloss.Renderer // Renderer the buttons are styled with
+	width    int                // Width available to the view, 0 means no limit
 }
 
 func New() Model {
 	return Model{
-		Prompt:    defaultPrompt,
-		value:     false,
-		confirmed: false,
-		keyMap:    DefaultKeyMap,
+		Prompt:           defaultPrompt,
+		value:            false,
+		confirmed:        false,
+		affirmativeLabel: defaultAffirmativeLabel,
+		negativeLabel:    defaultNegativeLabel,
+		keyMap:           DefaultKeyMap,
+		theme:            theme.Default,
+		renderer:         lipgloss.DefaultRenderer(),
 	}
 }
 
@@ -74,6 +80,43 @@ func (m Model) SetKeyMap(km KeyMap) Model {
 	return m
 }
 
+// SetRenderer sets the renderer the Yes/No buttons are styled with
+func (m Model) SetRenderer(r *lipgloss.Renderer) Model {
+	m.renderer = r
+	return m
+}
+
+// SetTheme sets the colors of the Yes/No buttons
+func (m Model) SetTheme(t theme.Theme) Model {
+	m.theme = t
+	return m
+}
+
+// SetWidth sets the width available to the view. The buttons are stacked vertically when they do not fit in it
+func (m Model) SetWidth(w int) Model {
+	m.width = w
+	return m
+}
+
+// SetLabels replaces the Yes and No labels, e.g. for translations
+func (m Model) SetLabels(affirmative, negative string) Model {
+	m.affirmativeLabel = affirmative
+	m.negativeLabel = negative
+	return m
+}
+
+// SetValue highlights Yes when b is true and No otherwise, e.g. to restore a previous answer
+func (m Model) SetValue(b bool) Model {
+	m.value = b
+	return m
+}
+
+// Reset clears the confirmation and keeps the current value so it can be confirmed again
+func (m Model) Reset() Model {
+	m.confirmed = false
+	return m
+}
+
 func (m Model) GetValue() bool {
 	return m.value
 }
@@ -82,42 +125,46 @@ func (m Model) IsConfirmed() bool {
 	return m.confirmed
 }
 
+// choice は現在の値を選択した2択のモデルを返す
+// 切り替えのキーは2択では次と前のどちらへの移動とも同じになる
+func (m Model) choice() choice.Model {
+	c, _ := choice.New(
+		choice.Option{Label: m.affirmativeLabel, Key: m.keyMap.Affirmative},
+		choice.Option{Label: m.negativeLabel, Key: m.keyMap.Negative},
+	)
+	c = c.SetKeyMap(choice.KeyMap{
+		Next:   m.keyMap.Toggle,
+		Select: m.keyMap.Select,
+		Quit:   m.keyMap.Quit,
+	}).SetTheme(m.theme).SetRenderer(m.renderer).SetWidth(m.width)
+	if !m.value {
+		c = c.SetDefault(1)
+	}
+	c.Prompt = m.Prompt
+	return c
+}
+
+// ShortHelp returns the bindings shown in the short help, implementing help.KeyMap
+func (m Model) ShortHelp() []key.Binding {
+	return m.choice().ShortHelp()
+}
+
+// FullHelp returns the bindings shown in the full help, implementing help.KeyMap
+func (m Model) FullHelp() [][]key.Binding {
+	return m.choice().FullHelp()
+}
+
 func (m Model) Init() tea.Cmd {
 	return nil
 }
 
 func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
-	switch msg := msg.(type) {
-	case tea.KeyMsg:
-		km := m.keyMap
-		switch {
-		case key.Matches(msg, km.Toggle):
-			m.value = !m.value
-		case key.Matches(msg, km.Affirmative):
-			m.value = true
-			m.confirmed = true
-		case key.Matches(msg, km.Negative):
-			m.value = false
-			m.confirmed = true
-		case key.Matches(msg, km.Select):
-			m.confirmed = true
-		case key.Matches(msg, km.Quit):
-			return m, tea.Quit
-		}
-	}
-
-	return m, nil
+	c, cmd := m.choice().Update(msg)
+	m.value = c.GetIndex() == 0
+	m.confirmed = m.confirmed || c.IsConfirmed()
+	return m, cmd
 }
 
 func (m Model) View() string {
-	var aff, neg string
-	if m.value {
-		aff = selectedStyle.Render("Yes")
-		neg = style.Render("No")
-	} else {
-		aff = style.Render("Yes")
-		neg = selectedStyle.Render("No")
-	}
-
-	return m.Prompt + "\n" + lipgloss.JoinHorizontal(lipgloss.Top, aff, neg)
+	return m.choice().View()
 }
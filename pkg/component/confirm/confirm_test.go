@@ -1,14 +1,19 @@
 package confirm
 
 import (
+	"io"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/cffnpwr/git-cz-go/pkg/theme"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/x/exp/teatest"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/muesli/termenv"
 )
 
 // ModelWrapper wraps Model to implement tea.Model interface properly
@@ -293,6 +298,15 @@ func TestSetters(t *testing.T) {
 	if !reflect.DeepEqual(keyMapModel.keyMap, customKeyMap) {
 		t.Errorf("SetKeyMap() = %v, want %v", keyMapModel.keyMap, customKeyMap)
 	}
+
+	// SetValue test
+	valueModel := model.SetValue(true)
+	if !valueModel.GetValue() {
+		t.Error("SetValue(true).GetValue() = false, want true")
+	}
+	if valueModel.IsConfirmed() {
+		t.Error("SetValue(true).IsConfirmed() = true, want false")
+	}
 }
 
 func TestGetters(t *testing.T) {
@@ -315,4 +329,107 @@ func TestGetters(t *testing.T) {
 	if model.IsConfirmed() != true {
 		t.Errorf("IsConfirmed() = %t, want %t", model.IsConfirmed(), true)
 	}
+
+	// Test reset keeps the value
+	model = model.Reset()
+	if model.GetValue() != true {
+		t.Errorf("GetValue() = %t after Reset(), want %t", model.GetValue(), true)
+	}
+	if model.IsConfirmed() != false {
+		t.Errorf("IsConfirmed() = %t after Reset(), want %t", model.IsConfirmed(), false)
+	}
+}
+
+func TestMouse(t *testing.T) {
+	tests := []struct {
+		name          string
+		prompt        string
+		msg           tea.MouseMsg
+		wantValue     bool
+		wantConfirmed bool
+	}{
+		{
+			name:          "[正常系] Yesをクリック",
+			prompt:        "Confirm",
+			msg:           tea.MouseMsg{X: 3, Y: 2, Action: tea.MouseActionPress, Button: tea.MouseButtonLeft},
+			wantValue:     true,
+			wantConfirmed: true,
+		},
+		{
+			name:          "[正常系] Noをクリック",
+			prompt:        "Confirm",
+			msg:           tea.MouseMsg{X: 12, Y: 2, Action: tea.MouseActionPress, Button: tea.MouseButtonLeft},
+			wantValue:     false,
+			wantConfirmed: true,
+		},
+		{
+			name:          "[正常系] 複数行のプロンプトの下のボタンをクリック",
+			prompt:        "Confirm\nthe message",
+			msg:           tea.MouseMsg{X: 1, Y: 3, Action: tea.MouseActionPress, Button: tea.MouseButtonLeft},
+			wantValue:     true,
+			wantConfirmed: true,
+		},
+		{
+			name:   "[異常系] ボタンの間の余白をクリック",
+			prompt: "Confirm",
+			msg:    tea.MouseMsg{X: 8, Y: 2, Action: tea.MouseActionPress, Button: tea.MouseButtonLeft},
+		},
+		{
+			name:   "[異常系] ボタンの上の余白をクリック",
+			prompt: "Confirm",
+			msg:    tea.MouseMsg{X: 3, Y: 1, Action: tea.MouseActionPress, Button: tea.MouseButtonLeft},
+		},
+		{
+			name:   "[異常系] クリック以外は無視",
+			prompt: "Confirm",
+			msg:    tea.MouseMsg{X: 3, Y: 2, Action: tea.MouseActionMotion},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := New()
+			model.Prompt = tt.prompt
+			model, _ = model.Update(tt.msg)
+
+			if model.GetValue() != tt.wantValue {
+				t.Errorf("GetValue() = %t, want %t", model.GetValue(), tt.wantValue)
+			}
+			if model.IsConfirmed() != tt.wantConfirmed {
+				t.Errorf("IsConfirmed() = %t, want %t", model.IsConfirmed(), tt.wantConfirmed)
+			}
+		})
+	}
+}
+
+func TestSetRenderer(t *testing.T) {
+	tests := []struct {
+		name      string
+		profile   termenv.Profile
+		wantColor bool
+	}{
+		{
+			name:      "[正常系] 色を扱える出力の renderer では色を付ける",
+			profile:   termenv.TrueColor,
+			wantColor: true,
+		},
+		{
+			name:      "[正常系] 色を扱えない出力の renderer では色を付けない",
+			profile:   termenv.Ascii,
+			wantColor: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := New()
+			r := lipgloss.NewRenderer(io.Discard)
+			r.SetColorProfile(tt.profile)
+			view := model.SetTheme(theme.Dark).SetRenderer(r).View()
+			// 24bit の前景色か背景色の指定が含まれるか
+			if got := strings.Contains(view, "8;2;"); got != tt.wantColor {
+				t.Errorf("View() colored = %v, want %v: %q", got, tt.wantColor, view)
+			}
+		})
+	}
 }
@@ -3,12 +3,17 @@ package selector
 import (
 	"errors"
 	"fmt"
+	"maps"
 	"math"
+	"slices"
+	"strings"
 
 	"github.com/cffnpwr/git-cz-go/internal/util"
+	"github.com/cffnpwr/git-cz-go/pkg/theme"
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 )
 
 const (
@@ -16,26 +21,53 @@ const (
 	basePadding   = 0
 )
 
-var (
-	fgColor = lipgloss.Color("#bb9af7")
+// styles は renderer から作る表示のスタイル。色はテーマから表示時に設定する
+type styles struct {
+	list         lipgloss.Style
+	compact      lipgloss.Style
+	prompt       lipgloss.Style
+	item         lipgloss.Style
+	selectedItem lipgloss.Style
+	match        lipgloss.Style
+	filterInfo   lipgloss.Style
+	disabled     lipgloss.Style
+	group        lipgloss.Style
+	description  lipgloss.Style
+	plain        lipgloss.Style
+}
 
-	style             = lipgloss.NewStyle().PaddingTop(2).PaddingBottom(2).PaddingLeft(2)
-	promptStyle       = lipgloss.NewStyle().Bold(true)
-	itemStyle         = lipgloss.NewStyle().PaddingLeft(basePadding + 2)
-	selectedItemStyle = lipgloss.NewStyle().PaddingLeft(basePadding).Foreground(fgColor)
-)
+func newStyles(r *lipgloss.Renderer) styles {
+	list := r.NewStyle().PaddingTop(2).PaddingBottom(2).PaddingLeft(2)
+	return styles{
+		list:         list,
+		compact:      list.PaddingTop(1).PaddingBottom(0),
+		prompt:       r.NewStyle().Bold(true),
+		item:         r.NewStyle().PaddingLeft(basePadding + 2),
+		selectedItem: r.NewStyle().PaddingLeft(basePadding),
+		match:        r.NewStyle().Underline(true).Bold(true),
+		filterInfo:   r.NewStyle(),
+		disabled:     r.NewStyle().Strikethrough(true),
+		group:        r.NewStyle().PaddingLeft(basePadding).Bold(true),
+		description:  r.NewStyle().PaddingLeft(basePadding + 2).Italic(true),
+		plain:        r.NewStyle(),
+	}
+}
 
 type KeyMap struct {
-	Up     key.Binding
-	Down   key.Binding
-	Select key.Binding
-	Quit   key.Binding
+	Up          key.Binding
+	Down        key.Binding
+	Select      key.Binding
+	Quit        key.Binding
+	ClearFilter key.Binding // Used only while a filter is typed, before Quit
+	Toggle      key.Binding // Used only in multi-select mode
+	SelectAll   key.Binding // Used only in multi-select mode
+	Invert      key.Binding // Used only in multi-select mode
 }
 
 var DefaultKeyMap = KeyMap{
 	Up: key.NewBinding(
 		key.WithKeys("k", "up"),
-		key.WithHelp("↑/k", "mode up"),
+		key.WithHelp("↑/k", "move up"),
 	),
 	Down: key.NewBinding(
 		key.WithKeys("j", "down"),
@@ -49,32 +81,82 @@ var DefaultKeyMap = KeyMap{
 		key.WithKeys("ctrl+c", "esc"),
 		key.WithHelp("Ctrl + C/Esc", "quit"),
 	),
+	ClearFilter: key.NewBinding(
+		key.WithKeys("esc"),
+		key.WithHelp("Esc", "clear filter"),
+	),
+	Toggle: key.NewBinding(
+		key.WithKeys("tab", " "),
+		key.WithHelp("tab/space", "toggle item"),
+	),
+	SelectAll: key.NewBinding(
+		key.WithKeys("a", "ctrl+a"),
+		key.WithHelp("a/Ctrl + A", "select all items"),
+	),
+	Invert: key.NewBinding(
+		key.WithKeys("i", "ctrl+r"),
+		key.WithHelp("i/Ctrl + R", "invert selection"),
+	),
 }
 
 type SelectItem interface {
 	fmt.Stringer
 }
 
+// RichItem is an optional SelectItem with a description shown under the list,
+// a group header it is listed under and a disabled state that can't be selected
+type RichItem interface {
+	SelectItem
+	Title() string       // Text shown in the list instead of String()
+	Description() string // Detail shown under the list while the cursor is on the item
+	Group() string       // Header the item is listed under, empty for no group
+	Disabled() bool      // Whether the item is skipped while navigating
+}
+
+// itemTitle は一覧に表示する項目の文字列を返す
+func itemTitle(item SelectItem) string {
+	if r, ok := item.(RichItem); ok && r.Title() != "" {
+		return r.Title()
+	}
+	return item.String()
+}
+
 type Model struct {
 	Prompt string // Question prompt
 
 	showSelectedItem bool // Flag for show selected item
 	selected         bool // Flag for selected
 	cyclic           bool // Flag for circular view
+	filterable       bool // Flag for narrowing the items by typing
+	multiSelect      bool // Flag for checking several items
+	hotkeys          bool // Flag for jumping to items with number and letter keys
+
+	checked      map[int]bool // Checked items keyed by the item index in multi-select mode
+	minSelection int          // Minimum number of checked items, 0 means no limit
+	maxSelection int          // Maximum number of checked items, 0 means no limit
+	err          string       // Message shown when a selection constraint is violated
 
 	items        []SelectItem // Selectable items
-	cursor       int          // Cursor position
-	displayRange [2]int       // Item display range
+	cursor       int          // Cursor position in the filtered items
+	displayRange [2]int       // Item display range in the filtered items
 	displaySize  int          // Item display size
+	width        int          // Width available to the view, 0 means no limit
+	height       int          // Height available to the view, 0 means no limit
 
-	keyMap KeyMap // key map
+	filter   string        // Text typed to narrow the items
+	filtered []int         // Indexes of the items matching the filter, nil while the filter is empty
+	matches  map[int][]int // Positions of the matched runes keyed by the item index
+
+	keyMap KeyMap      // key map
+	theme  theme.Theme // Colors
+	styles styles      // Styles built from the renderer
 }
 
 func New(items []SelectItem, displaySize int) (Model, error) {
 	if displaySize < 1 {
 		return Model{}, errors.New("invalid display size, must be positive integer")
 	}
-	return Model{
+	m := Model{
 		Prompt:           defaultPrompt,
 		showSelectedItem: false,
 		selected:         false,
@@ -84,7 +166,10 @@ func New(items []SelectItem, displaySize int) (Model, error) {
 		displayRange:     [2]int{0, displaySize},
 		displaySize:      displaySize,
 		keyMap:           DefaultKeyMap,
-	}, nil
+		theme:            theme.Default,
+		styles:           newStyles(lipgloss.DefaultRenderer()),
+	}
+	return m.skipDisabled(), nil
 }
 
 func (m Model) SetShowSelectedItem(b bool) Model {
@@ -97,81 +182,596 @@ func (m Model) SetCyclic(b bool) Model {
 	return m
 }
 
+// SetFilterable makes typed characters narrow the items with fuzzy matching instead of moving the cursor.
+// The cursor is moved with the arrow keys while filtering.
+func (m Model) SetFilterable(b bool) Model {
+	m.filterable = b
+	return m
+}
+
+// SetMultiSelect lets several items be checked with the toggle keys and confirmed with the select key.
+// The space key toggles an item instead of selecting it while the mode is enabled.
+func (m Model) SetMultiSelect(b bool) Model {
+	m.multiSelect = b
+	if b && m.checked == nil {
+		m.checked = map[int]bool{}
+	}
+	return m
+}
+
+// SetHotkeys enables the number keys selecting the n-th displayed item (toggling it in multi-select mode)
+// and the letter keys moving the cursor to the next item starting with the letter.
+// Keys bound in the key map keep their meaning. While the items are filterable only the number keys are hotkeys,
+// the letters being typed into the filter.
+func (m Model) SetHotkeys(b bool) Model {
+	m.hotkeys = b
+	return m
+}
+
+// SetCursor moves the cursor to the item at the index and scrolls it into view.
+// The cursor is kept when the index is out of range, disabled or filtered out.
+func (m Model) SetCursor(index int) Model {
+	if index < 0 || index >= len(m.items) {
+		return m
+	}
+	pos := index
+	if m.filtered != nil {
+		if pos = slices.Index(m.filtered, index); pos < 0 {
+			return m
+		}
+	}
+	if m.isDisabled(pos) {
+		return m
+	}
+	return m.setPosition(pos)
+}
+
+// SetSelectionLimits sets how many items must be checked before the selection can be confirmed.
+// A limit of 0 means no limit.
+func (m Model) SetSelectionLimits(minSelection, maxSelection int) (Model, error) {
+	if minSelection < 0 || maxSelection < 0 {
+		return m, errors.New("invalid selection limits, must not be negative")
+	}
+	if maxSelection > 0 && minSelection > maxSelection {
+		return m, errors.New("invalid selection limits, min must not exceed max")
+	}
+	m.minSelection = minSelection
+	m.maxSelection = maxSelection
+	return m, nil
+}
+
+// SetCheckedItems checks the items at the given indexes in multi-select mode
+func (m Model) SetCheckedItems(indexes ...int) Model {
+	m.checked = map[int]bool{}
+	for _, i := range indexes {
+		if i >= 0 && i < len(m.items) {
+			m.checked[i] = true
+		}
+	}
+	return m
+}
+
+// SetItems replaces the items, clearing the checked items and the filter
+func (m Model) SetItems(items []SelectItem) Model {
+	m.items = items
+	m.checked = map[int]bool{}
+	return m.setFilter("")
+}
+
 func (m Model) SetKeyMap(km KeyMap) Model {
 	m.keyMap = km
 	return m
 }
 
+// SetSize sets the space available to the view. 0 means no limit.
+// Rows wider than the width are cut with an ellipsis, and fewer items than the display size are shown
+// when the height cannot hold them.
+func (m Model) SetSize(width, height int) Model {
+	m.width = width
+	m.height = height
+	return m.setPosition(m.cursor)
+}
+
+// SetTheme sets the colors of the cursor, the hints, the group headers and the descriptions
+func (m Model) SetTheme(t theme.Theme) Model {
+	m.theme = t
+	return m
+}
+
+// SetRenderer sets the renderer the styles are built from, which detects the colors the output supports
+func (m Model) SetRenderer(r *lipgloss.Renderer) Model {
+	m.styles = newStyles(r)
+	return m
+}
+
+// Reset clears the selection and keeps the cursor so the item can be selected again
+func (m Model) Reset() Model {
+	m.selected = false
+	return m
+}
+
 func (m Model) IsSelected() bool {
 	return m.selected
 }
 
 func (m Model) GetSelectedItem() SelectItem {
 	if m.selected {
-		return m.items[m.cursor]
+		return m.items[m.itemIndex(m.cursor)]
 	}
 	return nil
 }
 
+// GetSelectedItems returns the checked items in their original order once the selection is confirmed in multi-select mode
+func (m Model) GetSelectedItems() []SelectItem {
+	if !m.selected || !m.multiSelect {
+		return nil
+	}
+	return m.GetCheckedItems()
+}
+
+// GetCheckedItems returns the checked items in their original order whether or not the selection has been confirmed
+func (m Model) GetCheckedItems() []SelectItem {
+	items := []SelectItem{}
+	for i, item := range m.items {
+		if m.checked[i] {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// ShortHelp returns the bindings shown in the short help, implementing help.KeyMap
+func (m Model) ShortHelp() []key.Binding {
+	km := m.keyMap
+	if m.multiSelect {
+		return append([]key.Binding{km.Up, km.Down, km.Toggle, km.Select}, m.exitKeys()...)
+	}
+	return append([]key.Binding{km.Up, km.Down, km.Select}, m.exitKeys()...)
+}
+
+// FullHelp returns the bindings shown in the full help, implementing help.KeyMap
+func (m Model) FullHelp() [][]key.Binding {
+	km := m.keyMap
+	if m.multiSelect {
+		return [][]key.Binding{{km.Up, km.Down}, {km.Toggle, km.SelectAll, km.Invert}, append([]key.Binding{km.Select}, m.exitKeys()...)}
+	}
+	return [][]key.Binding{{km.Up, km.Down}, append([]key.Binding{km.Select}, m.exitKeys()...)}
+}
+
+// IsFiltering reports whether a filter has been typed
+func (m Model) IsFiltering() bool {
+	return m.filter != ""
+}
+
+// exitKeys は終了のキーと、絞り込み中はその解除のキーを返す
+func (m Model) exitKeys() []key.Binding {
+	if m.IsFiltering() {
+		return []key.Binding{m.keyMap.ClearFilter, m.keyMap.Quit}
+	}
+	return []key.Binding{m.keyMap.Quit}
+}
+
+// GetCurrentItem returns the item under the cursor whether or not it has been selected
+func (m Model) GetCurrentItem() SelectItem {
+	if m.count() == 0 {
+		return nil
+	}
+	return m.items[m.itemIndex(m.cursor)]
+}
+
+// GetFilter returns the text typed to narrow the items
+func (m Model) GetFilter() string {
+	return m.filter
+}
+
+// isDisabled は絞り込み後の位置にある項目が無効かどうかを返す
+func (m Model) isDisabled(pos int) bool {
+	if pos < 0 || pos >= m.count() {
+		return false
+	}
+	r, ok := m.items[m.itemIndex(pos)].(RichItem)
+	return ok && r.Disabled()
+}
+
+// itemGroup は項目のグループ名を返す
+func (m Model) itemGroup(i int) string {
+	if r, ok := m.items[i].(RichItem); ok {
+		return r.Group()
+	}
+	return ""
+}
+
+// skipDisabled はカーソルが無効な項目にある場合に次の有効な項目へ移動する
+func (m Model) skipDisabled() Model {
+	if !m.isDisabled(m.cursor) {
+		return m
+	}
+	return m.moveSkipping(Model.moveDown)
+}
+
+// moveSkipping は無効な項目を飛ばしてカーソルを移動する。有効な項目が無い場合は移動しない
+func (m Model) moveSkipping(move func(Model) Model) Model {
+	next := move(m)
+	for range m.count() {
+		if !next.isDisabled(next.cursor) {
+			return next
+		}
+		next = move(next)
+	}
+	return m
+}
+
+// setPosition はカーソルを移動し、スクロールした場合と同じ位置に表示されるよう表示範囲を計算し直す
+func (m Model) setPosition(pos int) Model {
+	count := m.count()
+	size := m.viewSize()
+	m.cursor = pos
+	if count <= size {
+		m.displayRange = [2]int{0, size}
+		return m
+	}
+
+	if m.cyclic {
+		// 下へ移動し続けた場合のカーソルの表示位置に合わせる
+		offset := min(pos, int(math.Ceil(float64(size)/2))-1)
+		mod := util.GenMod(count)
+		start := mod(pos - offset)
+		m.displayRange = [2]int{start, mod(start + size)}
+		return m
+	}
+	start := max(0, min(pos-size/2, count-size))
+	m.displayRange = [2]int{start, start + size}
+	return m
+}
+
+// isNumberKey は表示順で項目を選ぶ1〜9のキーかを返す
+func isNumberKey(msg tea.KeyMsg) bool {
+	return msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] >= '1' && msg.Runes[0] <= '9'
+}
+
+// hotkey は数字や文字のキーに対応する項目へカーソルを移動する
+func (m Model) hotkey(r rune) Model {
+	if r >= '1' && r <= '9' {
+		positions := m.displayedPositions()
+		n := int(r - '1')
+		if n >= len(positions) || m.isDisabled(positions[n]) {
+			return m
+		}
+		m.cursor = positions[n]
+		if m.multiSelect {
+			return m.setChecked([]int{m.cursor}, func(checked bool) bool { return !checked })
+		}
+		m.selected = true
+		return m
+	}
+
+	// カーソルの次の項目から順に、その文字で始まる項目を探す
+	count := m.count()
+	prefix := strings.ToLower(string(r))
+	for i := 1; i <= count; i++ {
+		pos := (m.cursor + i) % count
+		title := strings.ToLower(itemTitle(m.items[m.itemIndex(pos)]))
+		if strings.HasPrefix(title, prefix) && !m.isDisabled(pos) {
+			return m.setPosition(pos)
+		}
+	}
+	return m
+}
+
+// displayedPositions は表示範囲にある項目の位置を表示順に返す
+func (m Model) displayedPositions() []int {
+	start := m.displayRange[0]
+	end := min(m.displayRange[1], m.count())
+	var positions []int
+	if start >= end {
+		for pos := start; pos < m.count(); pos++ {
+			positions = append(positions, pos)
+		}
+		for pos := 0; pos < end; pos++ {
+			positions = append(positions, pos)
+		}
+	} else {
+		for pos := start; pos < end; pos++ {
+			positions = append(positions, pos)
+		}
+	}
+	return positions
+}
+
+// middle は表示中の項目の中央の位置を返す
+func (m Model) middle() int {
+	mod := util.GenMod(m.count())
+	if m.cyclic {
+		return mod(int(math.Ceil(float64(m.viewSize())/2)) + m.displayRange[0])
+	}
+	return mod(int(math.Floor(float64(m.viewSize())/2)) + m.displayRange[0])
+}
+
+// moveUp はカーソルを一つ上に移動し、必要に応じて表示範囲をずらす
+func (m Model) moveUp() Model {
+	mod := util.GenMod(m.count())
+	middle := m.middle()
+	if m.cyclic {
+		m.cursor = mod(m.cursor - 1)
+
+		m.displayRange[0] = mod(m.displayRange[0] - 1)
+		m.displayRange[1] = mod(m.displayRange[1] - 1)
+	} else {
+		if m.displayRange[0] != 0 && m.cursor <= middle {
+			m.displayRange[0] -= 1
+			m.displayRange[1] -= 1
+		}
+		if m.cursor != 0 {
+			m.cursor -= 1
+		}
+	}
+	return m
+}
+
+// moveDown はカーソルを一つ下に移動し、必要に応じて表示範囲をずらす
+func (m Model) moveDown() Model {
+	count := m.count()
+	mod := util.GenMod(count)
+	middle := m.middle()
+	if m.cyclic {
+		m.cursor = mod(m.cursor + 1)
+
+		if m.cursor >= middle {
+			m.displayRange[0] = mod(m.displayRange[0] + 1)
+			m.displayRange[1] = mod(m.displayRange[1] + 1)
+		}
+	} else {
+		if m.displayRange[1] != count && m.cursor >= middle {
+			m.displayRange[0] += 1
+			m.displayRange[1] += 1
+		}
+		if m.cursor != count-1 {
+			m.cursor += 1
+		}
+	}
+	return m
+}
+
+// submit は選択を確定する。複数選択でチェックされた項目が下限に満たない場合は確定しない
+func (m Model) submit() Model {
+	if m.multiSelect && m.checkedCount() < m.minSelection {
+		m.err = fmt.Sprintf("Select at least %d items", m.minSelection)
+		return m
+	}
+	m.err = ""
+	m.selected = true
+	return m
+}
+
+// checkedCount はチェックされた項目数を返す
+func (m Model) checkedCount() int {
+	n := 0
+	for _, ok := range m.checked {
+		if ok {
+			n++
+		}
+	}
+	return n
+}
+
+// setChecked は表示中の項目のチェック状態を変更する。上限を超える場合は変更しない
+func (m Model) setChecked(positions []int, f func(checked bool) bool) Model {
+	checked := maps.Clone(m.checked)
+	if checked == nil {
+		checked = map[int]bool{}
+	}
+	for _, pos := range positions {
+		i := m.itemIndex(pos)
+		checked[i] = f(checked[i])
+	}
+
+	m.err = ""
+	n := 0
+	for _, ok := range checked {
+		if ok {
+			n++
+		}
+	}
+	if m.maxSelection > 0 && n > m.maxSelection {
+		m.err = fmt.Sprintf("Select at most %d items", m.maxSelection)
+		return m
+	}
+	m.checked = checked
+	return m
+}
+
+// enabledPositions は絞り込み後の有効な項目の位置を返す
+func (m Model) enabledPositions() []int {
+	var positions []int
+	for pos := range m.count() {
+		if !m.isDisabled(pos) {
+			positions = append(positions, pos)
+		}
+	}
+	return positions
+}
+
+// count は絞り込み後の項目数を返す
+func (m Model) count() int {
+	if m.filtered != nil {
+		return len(m.filtered)
+	}
+	return len(m.items)
+}
+
+// itemIndex は絞り込み後の位置に対応する項目のインデックスを返す
+func (m Model) itemIndex(pos int) int {
+	if m.filtered != nil {
+		return m.filtered[pos]
+	}
+	return pos
+}
+
+// viewSize は一度に表示する項目数を返す
+func (m Model) viewSize() int {
+	size := m.displaySize
+	if m.height > 0 {
+		size = max(1, min(size, m.fittingRows(m.listStyle())))
+	}
+	return min(size, m.count())
+}
+
+// fittingRows は一覧の余白をスタイルに合わせた場合に高さに収まる項目数を返す
+func (m Model) fittingRows(s lipgloss.Style) int {
+	// プロンプトの最終行は上の余白と同じ行に続き、一覧の最後の改行で1行増える
+	rows := m.height - (lipgloss.Height(m.Prompt) - 1 + s.GetVerticalPadding() + 1)
+	// 位置、説明、複数選択の件数の行
+	rows -= 2
+	if m.multiSelect {
+		rows--
+	}
+	// 見出しは表示する項目数とグループ数の少ない方の行数を超えない
+	return max(rows-m.groupCount(), rows/2)
+}
+
+// groupCount は項目のグループの数を返す
+func (m Model) groupCount() int {
+	groups := map[string]bool{}
+	for i := range m.items {
+		if g := m.itemGroup(i); g != "" {
+			groups[g] = true
+		}
+	}
+	return len(groups)
+}
+
+// listStyle は高さが足りない場合に一覧の上下の余白を詰めたスタイルを返す
+func (m Model) listStyle() lipgloss.Style {
+	if m.height > 0 && m.fittingRows(m.styles.list) < m.displaySize {
+		return m.styles.compact
+	}
+	return m.styles.list
+}
+
+// rowWidth は一覧の左の余白を除いた各行の幅を返す。制限が無い場合は0
+func (m Model) rowWidth() int {
+	if m.width <= 0 {
+		return 0
+	}
+	return max(1, m.width-m.listStyle().GetPaddingLeft())
+}
+
+// truncate は幅を超える行を表示幅で切り詰めて省略記号を付ける
+func truncate(s string, width int) string {
+	if width <= 0 {
+		return s
+	}
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = ansi.Truncate(line, width, "…")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// setFilter は入力された文字列に一致する項目をスコアの高い順に並べ、先頭から表示し直す
+func (m Model) setFilter(filter string) Model {
+	m.filter = filter
+	m.filtered, m.matches = nil, nil
+	if filter != "" {
+		scores := map[int]int{}
+		m.filtered = []int{}
+		m.matches = map[int][]int{}
+		for i, item := range m.items {
+			if score, positions, ok := util.FuzzyMatch(filter, itemTitle(item)); ok {
+				m.filtered = append(m.filtered, i)
+				scores[i] = score
+				m.matches[i] = positions
+			}
+		}
+		slices.SortStableFunc(m.filtered, func(a, b int) int { return scores[b] - scores[a] })
+	}
+
+	m.cursor = 0
+	m.displayRange = [2]int{0, m.viewSize()}
+	return m.skipDisabled()
+}
+
+// updateFilter は絞り込みの文字列を編集するキーを処理する
+func (m Model) updateFilter(msg tea.KeyMsg) (Model, bool) {
+	switch msg.Type {
+	case tea.KeyRunes:
+		return m.setFilter(m.filter + string(msg.Runes)), true
+	case tea.KeySpace:
+		return m.setFilter(m.filter + " "), true
+	case tea.KeyBackspace:
+		if r := []rune(m.filter); len(r) > 0 {
+			return m.setFilter(string(r[:len(r)-1])), true
+		}
+		return m, true
+	}
+	// 絞り込み中は終了より先に絞り込みを解除する
+	if m.IsFiltering() && key.Matches(msg, m.keyMap.ClearFilter) {
+		return m.setFilter(""), true
+	}
+	return m, false
+}
+
 func (m Model) Init() tea.Cmd {
 	return nil
 }
 
 func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	switch msg := msg.(type) {
+	case tea.MouseMsg:
+		return m.updateMouse(msg), nil
 	case tea.KeyMsg:
+		if m.filterable && !m.selected {
+			// 絞り込みが有効な場合は数字のみをホットキーとして扱う
+			if m.hotkeys && isNumberKey(msg) {
+				return m.hotkey(msg.Runes[0]), nil
+			}
+			var handled bool
+			if m, handled = m.updateFilter(msg); handled {
+				return m, nil
+			}
+		}
+
 		km := m.keyMap
-		mod := util.GenMod(len(m.items))
-		// middle of displayed items
-		var middle int
-		if m.cyclic {
-			middle = mod(int(math.Ceil(float64(m.displaySize)/2)) + m.displayRange[0])
-		} else {
-			middle = mod(int(math.Floor(float64(m.displaySize)/2)) + m.displayRange[0])
+		count := m.count()
+		if count == 0 {
+			// 一致する項目が無い場合は終了と、複数選択ではチェック済みの項目での確定のみ受け付ける
+			switch {
+			case key.Matches(msg, km.Quit):
+				return m, tea.Quit
+			case m.multiSelect && key.Matches(msg, km.Select):
+				m = m.submit()
+			}
+			return m, nil
 		}
 		switch {
 		case key.Matches(msg, km.Up):
 			if m.selected {
 				return m, nil
 			}
-			if m.cyclic {
-				m.cursor = mod(m.cursor - 1)
-
-				m.displayRange[0] = mod(m.displayRange[0] - 1)
-				m.displayRange[1] = mod(m.displayRange[1] - 1)
-			} else {
-				if m.displayRange[0] != 0 && m.cursor <= middle {
-					m.displayRange[0] -= 1
-					m.displayRange[1] -= 1
-				}
-				if m.cursor != 0 {
-					m.cursor -= 1
-				}
-			}
+			m = m.moveSkipping(Model.moveUp)
 		case key.Matches(msg, km.Down):
 			if m.selected {
 				return m, nil
 			}
-			if m.cyclic {
-				m.cursor = mod(m.cursor + 1)
-
-				if m.cursor >= middle {
-					m.displayRange[0] = mod(m.displayRange[0] + 1)
-					m.displayRange[1] = mod(m.displayRange[1] + 1)
-				}
-			} else {
-				if m.displayRange[1] != len(m.items) && m.cursor >= middle {
-					m.displayRange[0] += 1
-					m.displayRange[1] += 1
-				}
-				if m.cursor != len(m.items)-1 {
-					m.cursor += 1
-				}
+			m = m.moveSkipping(Model.moveDown)
+		case m.multiSelect && !m.selected && key.Matches(msg, km.Toggle):
+			if !m.isDisabled(m.cursor) {
+				m = m.setChecked([]int{m.cursor}, func(checked bool) bool { return !checked })
 			}
+		case m.multiSelect && !m.selected && key.Matches(msg, km.SelectAll):
+			m = m.setChecked(m.enabledPositions(), func(bool) bool { return true })
+		case m.multiSelect && !m.selected && key.Matches(msg, km.Invert):
+			m = m.setChecked(m.enabledPositions(), func(checked bool) bool { return !checked })
 		case key.Matches(msg, km.Select):
-			m.selected = true
+			if !m.multiSelect && m.isDisabled(m.cursor) {
+				// 有効な項目が無い場合は選択できない
+				return m, nil
+			}
+			m = m.submit()
 		case key.Matches(msg, km.Quit):
 			return m, tea.Quit
+		case m.hotkeys && !m.selected && msg.Type == tea.KeyRunes && len(msg.Runes) == 1:
+			m = m.hotkey(msg.Runes[0])
 		}
 	}
 
@@ -179,37 +779,171 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 }
 
 func (m Model) View() string {
-	mod := util.GenMod(len(m.items))
-
 	qStr := m.Prompt + ": "
-	qStr = promptStyle.Render(qStr)
+	qStr = m.styles.prompt.Render(qStr)
 	if m.selected {
 		if !m.showSelectedItem {
 			return ""
 		}
 
-		selected := m.items[m.cursor]
-		return qStr + selectedItemStyle.Render(selected.String())
+		if m.multiSelect {
+			var names []string
+			for _, item := range m.GetCheckedItems() {
+				names = append(names, itemTitle(item))
+			}
+			return truncate(qStr+m.styles.selectedItem.Foreground(m.theme.Accent).Render(strings.Join(names, ", ")), m.width)
+		}
+		selected := m.items[m.itemIndex(m.cursor)]
+		return truncate(qStr+m.styles.selectedItem.Foreground(m.theme.Accent).Render(itemTitle(selected)), m.width)
 	}
 
-	start := m.displayRange[0]
-	end := m.displayRange[1]
-	displayItems := []SelectItem{}
-	if start >= end {
-		displayItems = append(displayItems, m.items[start:]...)
-		displayItems = append(displayItems, m.items[:end]...)
-	} else {
-		displayItems = m.items[start:end]
+	infoStyle := m.styles.filterInfo.Foreground(m.theme.Muted)
+	if m.filterable {
+		qStr += m.filter + infoStyle.Render(m.filterInfo())
+	}
+	// プロンプトの最終行には一覧の余白の行が続くため、まとめて切り詰める
+	listStyle := m.listStyle()
+	if m.count() == 0 {
+		return truncate(qStr+listStyle.Render(m.styles.item.Render(infoStyle.Render("No matching items"))+"\n"), m.width)
 	}
 
 	var selectStr string
-	for index, i := range displayItems {
-		itemStr := itemStyle.Render(i.String())
-		if m.cursor == mod(index+start) {
-			itemStr = selectedItemStyle.Render("> " + i.String())
+	rows, _ := m.listRows()
+	for _, row := range rows {
+		selectStr += row + "\n"
+	}
+	if m.count() > m.viewSize() {
+		selectStr += m.styles.item.Render(infoStyle.Render(fmt.Sprintf("%d/%d", m.cursor+1, m.count()))) + "\n"
+	}
+	if r, ok := m.GetCurrentItem().(RichItem); ok && r.Description() != "" && !m.isDisabled(m.cursor) {
+		selectStr += truncate(m.styles.description.Foreground(m.theme.Description).Render(r.Description()), m.rowWidth()) + "\n"
+	}
+	if m.multiSelect {
+		info := fmt.Sprintf("%d selected", m.checkedCount())
+		if m.err != "" {
+			info += " - " + m.err
 		}
-		selectStr += itemStr + "\n"
+		selectStr += truncate(m.styles.item.Render(infoStyle.Render(info)), m.rowWidth()) + "\n"
 	}
 
-	return qStr + style.Render(selectStr)
+	return truncate(qStr+listStyle.Render(selectStr), m.width)
+}
+
+// listRows は一覧の各行と、行に表示した項目の位置を返す。見出しの行の位置は-1とする
+func (m Model) listRows() ([]string, []int) {
+	var rows []string
+	var positions []int
+	var group string
+	for index, pos := range m.displayedPositions() {
+		i := m.itemIndex(pos)
+		// 並び順が変わる絞り込み中はグループの見出しを表示しない
+		if g := m.itemGroup(i); m.filtered == nil && g != "" && (index == 0 || g != group) {
+			rows = append(rows, m.styles.group.Foreground(m.theme.Group).Render("── "+g+" ──"))
+			positions = append(positions, -1)
+		}
+		group = m.itemGroup(i)
+
+		prefix := m.hotkeyLabel(index) + m.checkbox(i)
+		itemStr := m.styles.item.Render(prefix + m.itemView(i, m.styles.plain))
+		if m.isDisabled(pos) {
+			itemStr = m.styles.item.Render(prefix + m.itemView(i, m.styles.disabled.Foreground(m.theme.Muted)))
+		} else if pos == m.cursor {
+			// 強調した文字の後で色が戻らないように文字ごとに色を付ける
+			itemStr = m.styles.selectedItem.Foreground(m.theme.Accent).Render("> "+prefix) + m.itemView(i, m.styles.plain.Foreground(m.theme.Accent))
+		}
+		rows = append(rows, itemStr)
+		positions = append(positions, pos)
+	}
+	for i, row := range rows {
+		rows[i] = truncate(row, m.rowWidth())
+	}
+	return rows, positions
+}
+
+// positionAt は表示上の座標にある項目の位置を返す。座標はViewの左上を原点とする
+func (m Model) positionAt(x, y int) (int, bool) {
+	// プロンプトの最終行は一覧の上の余白と同じ行に続けて表示している
+	row := y - (lipgloss.Height(m.Prompt) - 1) - m.listStyle().GetPaddingTop()
+	rows, positions := m.listRows()
+	if row < 0 || row >= len(rows) || positions[row] < 0 {
+		return 0, false
+	}
+	left := m.listStyle().GetPaddingLeft()
+	if x < left || x >= left+lipgloss.Width(rows[row]) {
+		return 0, false
+	}
+	return positions[row], true
+}
+
+// updateMouse はクリックで項目を選択し、ホイールでカーソルを移動する
+func (m Model) updateMouse(msg tea.MouseMsg) Model {
+	if m.selected || m.count() == 0 || msg.Action != tea.MouseActionPress {
+		return m
+	}
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		return m.moveSkipping(Model.moveUp)
+	case tea.MouseButtonWheelDown:
+		return m.moveSkipping(Model.moveDown)
+	case tea.MouseButtonLeft:
+		pos, ok := m.positionAt(msg.X, msg.Y)
+		if !ok || m.isDisabled(pos) {
+			return m
+		}
+		m.cursor = pos
+		if m.multiSelect {
+			return m.setChecked([]int{pos}, func(checked bool) bool { return !checked })
+		}
+		m.selected = true
+	}
+	return m
+}
+
+// filterInfo は絞り込みの状態を表示する文字列を返す
+func (m Model) filterInfo() string {
+	if m.filter == "" {
+		return "(type to filter)"
+	}
+	return fmt.Sprintf(" (%d/%d)", m.count(), len(m.items))
+}
+
+// hotkeyLabel は表示順に対応する数字のキーを返す
+func (m Model) hotkeyLabel(index int) string {
+	if !m.hotkeys {
+		return ""
+	}
+	if index < 9 {
+		return fmt.Sprintf("%d ", index+1)
+	}
+	return "  "
+}
+
+// checkbox は複数選択の場合にチェック欄を返す
+func (m Model) checkbox(i int) string {
+	if !m.multiSelect {
+		return ""
+	}
+	if m.checked[i] {
+		return "[x] "
+	}
+	return "[ ] "
+}
+
+// itemView は項目を表示し、絞り込みに一致した文字を強調する
+func (m Model) itemView(i int, base lipgloss.Style) string {
+	s := itemTitle(m.items[i])
+	matched := m.matches[i]
+	if len(matched) == 0 {
+		return base.Render(s)
+	}
+
+	var b strings.Builder
+	for pos, r := range []rune(s) {
+		if slices.Contains(matched, pos) {
+			b.WriteString(m.styles.match.Inherit(base).Render(string(r)))
+		} else {
+			b.WriteString(base.Render(string(r)))
+		}
+	}
+	return b.String()
 }
@@ -2,14 +2,20 @@ package selector
 
 import (
 	"errors"
+	"io"
 	"reflect"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/cffnpwr/git-cz-go/pkg/theme"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/x/exp/teatest"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/muesli/termenv"
 )
 
 type testItem struct {
@@ -381,7 +387,7 @@ func TestView(t *testing.T) {
 
 			if tt.selected && tt.showSelectedItem {
 				expectedItem := tt.items[tt.cursor].String()
-				expectedView := promptStyle.Render(model.Prompt+": ") + expectedItem
+				expectedView := model.styles.prompt.Render(model.Prompt+": ") + expectedItem
 				if view != expectedView {
 					t.Errorf("expected %q, got: %q", expectedView, view)
 				}
@@ -389,3 +395,1101 @@ func TestView(t *testing.T) {
 		})
 	}
 }
+
+func TestSetItems(t *testing.T) {
+	tests := []struct {
+		name      string
+		items     []SelectItem
+		keyInputs []tea.KeyMsg
+		wantShown []string
+	}{
+		{
+			name:      "[正常系] 少ない項目に差し替え",
+			items:     createTestItems(2),
+			wantShown: []string{"A", "B"},
+		},
+		{
+			name:      "[正常系] チェックと絞り込みを消して差し替え",
+			items:     createTestItems(5),
+			keyInputs: []tea.KeyMsg{{Type: tea.KeyTab}, {Type: tea.KeyRunes, Runes: []rune("a")}},
+			wantShown: []string{"A", "B", "C", "D"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model, err := New(createTestItems(1), 4)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			model = model.SetMultiSelect(true).SetFilterable(true)
+			for _, msg := range tt.keyInputs {
+				model, _ = model.Update(msg)
+			}
+
+			model = model.SetItems(tt.items)
+			if got := model.GetCheckedItems(); len(got) != 0 {
+				t.Errorf("GetCheckedItems() = %v, want none", got)
+			}
+			if got := model.GetFilter(); got != "" {
+				t.Errorf("GetFilter() = %q, want empty", got)
+			}
+			var shown []string
+			for _, pos := range model.displayedPositions() {
+				shown = append(shown, model.items[model.itemIndex(pos)].String())
+			}
+			if diff := cmp.Diff(tt.wantShown, shown); diff != "" {
+				t.Errorf("displayedPositions() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReset(t *testing.T) {
+	model, err := New(createTestItems(3), 3)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if !model.IsSelected() {
+		t.Fatal("IsSelected() = false, want true")
+	}
+
+	if got := model.GetCurrentItem(); got != model.items[1] {
+		t.Errorf("GetCurrentItem() = %v, want %v", got, model.items[1])
+	}
+
+	model = model.Reset()
+	if model.IsSelected() {
+		t.Error("IsSelected() = true after Reset(), want false")
+	}
+	if model.cursor != 1 {
+		t.Errorf("cursor = %d after Reset(), want 1", model.cursor)
+	}
+}
+
+func createFilterTestItems() []SelectItem {
+	return []SelectItem{
+		testItem{value: "feat: add feature"},
+		testItem{value: "fix: bug fix"},
+		testItem{value: "docs: documentation"},
+		testItem{value: "refactor: restructure"},
+		testItem{value: "revert: revert a commit"},
+	}
+}
+
+func TestFilter(t *testing.T) {
+	tests := []struct {
+		name         string
+		cyclic       bool
+		keyInputs    []tea.KeyMsg
+		wantFilter   string
+		wantCount    int
+		wantCurrent  string
+		wantSelected bool
+	}{
+		{
+			name: "[正常系] 入力した文字で絞り込む",
+			keyInputs: []tea.KeyMsg{
+				{Type: tea.KeyRunes, Runes: []rune("rvt")},
+			},
+			wantFilter:  "rvt",
+			wantCount:   1,
+			wantCurrent: "revert: revert a commit",
+		},
+		{
+			name: "[正常系] j/kは絞り込みの文字として扱う",
+			keyInputs: []tea.KeyMsg{
+				{Type: tea.KeyRunes, Runes: []rune("j")},
+			},
+			wantFilter: "j",
+			wantCount:  0,
+		},
+		{
+			name: "[正常系] 一致度の高い順に並べる",
+			keyInputs: []tea.KeyMsg{
+				{Type: tea.KeyRunes, Runes: []rune("fe")},
+			},
+			wantFilter:  "fe",
+			wantCount:   2,
+			wantCurrent: "feat: add feature",
+		},
+		{
+			name: "[正常系] Backspaceで絞り込みを広げる",
+			keyInputs: []tea.KeyMsg{
+				{Type: tea.KeyRunes, Runes: []rune("rvt")},
+				{Type: tea.KeyBackspace},
+				{Type: tea.KeyBackspace},
+			},
+			wantFilter:  "r",
+			wantCount:   3,
+			wantCurrent: "refactor: restructure",
+		},
+		{
+			name: "[正常系] Escで絞り込みを解除",
+			keyInputs: []tea.KeyMsg{
+				{Type: tea.KeyRunes, Runes: []rune("rvt")},
+				{Type: tea.KeyEsc},
+			},
+			wantFilter:  "",
+			wantCount:   5,
+			wantCurrent: "feat: add feature",
+		},
+		{
+			name:   "[正常系] 絞り込んだ項目内で循環する",
+			cyclic: true,
+			keyInputs: []tea.KeyMsg{
+				{Type: tea.KeyRunes, Runes: []rune("re")},
+				{Type: tea.KeyUp},
+			},
+			wantFilter:  "re",
+			wantCount:   3,
+			wantCurrent: "feat: add feature",
+		},
+		{
+			name: "[正常系] 絞り込んだ項目を選択",
+			keyInputs: []tea.KeyMsg{
+				{Type: tea.KeyRunes, Runes: []rune("doc")},
+				{Type: tea.KeyEnter},
+			},
+			wantFilter:   "doc",
+			wantCount:    1,
+			wantCurrent:  "docs: documentation",
+			wantSelected: true,
+		},
+		{
+			name: "[異常系] 一致する項目が無い場合は選択できない",
+			keyInputs: []tea.KeyMsg{
+				{Type: tea.KeyRunes, Runes: []rune("xyz")},
+				{Type: tea.KeyDown},
+				{Type: tea.KeyEnter},
+			},
+			wantFilter: "xyz",
+			wantCount:  0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model, err := New(createFilterTestItems(), 3)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			model = model.SetFilterable(true).SetCyclic(tt.cyclic).SetShowSelectedItem(true)
+			for _, msg := range tt.keyInputs {
+				model, _ = model.Update(msg)
+			}
+
+			if got := model.GetFilter(); got != tt.wantFilter {
+				t.Errorf("GetFilter() = %q, want %q", got, tt.wantFilter)
+			}
+			if got := model.count(); got != tt.wantCount {
+				t.Errorf("count() = %d, want %d", got, tt.wantCount)
+			}
+			var current string
+			if item := model.GetCurrentItem(); item != nil {
+				current = item.String()
+			}
+			if current != tt.wantCurrent {
+				t.Errorf("GetCurrentItem() = %q, want %q", current, tt.wantCurrent)
+			}
+			if model.IsSelected() != tt.wantSelected {
+				t.Errorf("IsSelected() = %t, want %t", model.IsSelected(), tt.wantSelected)
+			}
+			// 表示範囲が絞り込んだ項目数を超えないこと
+			if view := model.View(); view == "" {
+				t.Error("expected non-empty view")
+			}
+		})
+	}
+}
+
+func TestMultiSelect(t *testing.T) {
+	tests := []struct {
+		name         string
+		min, max     int
+		filterable   bool
+		keyInputs    []tea.KeyMsg
+		wantChecked  []string
+		wantSelected bool
+		wantErr      string
+	}{
+		{
+			name: "[正常系] スペースで項目をチェックして確定",
+			keyInputs: []tea.KeyMsg{
+				{Type: tea.KeySpace},
+				{Type: tea.KeyDown},
+				{Type: tea.KeyDown},
+				{Type: tea.KeyTab},
+				{Type: tea.KeyEnter},
+			},
+			wantChecked:  []string{"A", "C"},
+			wantSelected: true,
+		},
+		{
+			name: "[正常系] 再度トグルするとチェックを外す",
+			keyInputs: []tea.KeyMsg{
+				{Type: tea.KeyTab},
+				{Type: tea.KeyTab},
+			},
+			wantChecked: []string{},
+		},
+		{
+			name: "[正常系] すべて選択",
+			keyInputs: []tea.KeyMsg{
+				{Type: tea.KeyRunes, Runes: []rune("a")},
+			},
+			wantChecked: []string{"A", "B", "C", "D"},
+		},
+		{
+			name: "[正常系] 選択を反転",
+			keyInputs: []tea.KeyMsg{
+				{Type: tea.KeyTab},
+				{Type: tea.KeyRunes, Runes: []rune("i")},
+			},
+			wantChecked: []string{"B", "C", "D"},
+		},
+		{
+			name:       "[正常系] 絞り込み中は表示中の項目だけを対象にする",
+			filterable: true,
+			keyInputs: []tea.KeyMsg{
+				{Type: tea.KeyRunes, Runes: []rune("b")},
+				{Type: tea.KeyCtrlA},
+				{Type: tea.KeyBackspace},
+			},
+			wantChecked: []string{"B"},
+		},
+		{
+			name:       "[正常系] 一致する項目が無くてもチェック済みの項目で確定",
+			filterable: true,
+			keyInputs: []tea.KeyMsg{
+				{Type: tea.KeyTab},
+				{Type: tea.KeyRunes, Runes: []rune("z")},
+				{Type: tea.KeyEnter},
+			},
+			wantChecked:  []string{"A"},
+			wantSelected: true,
+		},
+		{
+			name: "[異常系] 最小数に満たない場合は確定できない",
+			min:  2,
+			keyInputs: []tea.KeyMsg{
+				{Type: tea.KeyTab},
+				{Type: tea.KeyEnter},
+			},
+			wantChecked: []string{"A"},
+			wantErr:     "Select at least 2 items",
+		},
+		{
+			name: "[異常系] 最大数を超えてチェックできない",
+			max:  1,
+			keyInputs: []tea.KeyMsg{
+				{Type: tea.KeyTab},
+				{Type: tea.KeyDown},
+				{Type: tea.KeyTab},
+			},
+			wantChecked: []string{"A"},
+			wantErr:     "Select at most 1 items",
+		},
+		{
+			name: "[異常系] 最大数を超える場合はすべて選択できない",
+			max:  3,
+			keyInputs: []tea.KeyMsg{
+				{Type: tea.KeyRunes, Runes: []rune("a")},
+			},
+			wantChecked: []string{},
+			wantErr:     "Select at most 3 items",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model, err := New(createTestItems(4), 3)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			model, err = model.SetMultiSelect(true).SetFilterable(tt.filterable).SetSelectionLimits(tt.min, tt.max)
+			if err != nil {
+				t.Fatalf("SetSelectionLimits() error = %v", err)
+			}
+			for _, msg := range tt.keyInputs {
+				model, _ = model.Update(msg)
+			}
+
+			var checked []string
+			for _, item := range model.GetCheckedItems() {
+				checked = append(checked, item.String())
+			}
+			if diff := cmp.Diff(tt.wantChecked, checked, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("GetCheckedItems() mismatch (-want +got):\n%s", diff)
+			}
+			if model.IsSelected() != tt.wantSelected {
+				t.Errorf("IsSelected() = %t, want %t", model.IsSelected(), tt.wantSelected)
+			}
+			if model.err != tt.wantErr {
+				t.Errorf("err = %q, want %q", model.err, tt.wantErr)
+			}
+			if !tt.wantSelected && model.GetSelectedItems() != nil {
+				t.Error("GetSelectedItems() should be nil before the selection is confirmed")
+			}
+		})
+	}
+}
+
+func TestSetSelectionLimits(t *testing.T) {
+	tests := []struct {
+		name      string
+		min, max  int
+		wantError bool
+	}{
+		{name: "[正常系] 制限なし", min: 0, max: 0},
+		{name: "[正常系] 最小数のみ", min: 2, max: 0},
+		{name: "[正常系] 最小数と最大数が同じ", min: 2, max: 2},
+		{name: "[異常系] 負の値", min: -1, max: 0, wantError: true},
+		{name: "[異常系] 最小数が最大数を超える", min: 3, max: 2, wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model, err := New(createTestItems(3), 3)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			if _, err := model.SetSelectionLimits(tt.min, tt.max); (err != nil) != tt.wantError {
+				t.Errorf("SetSelectionLimits() error = %v, wantError %t", err, tt.wantError)
+			}
+		})
+	}
+}
+
+func TestMultiSelectProgram(t *testing.T) {
+	model, err := New(createTestItems(5), 3)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	model = model.SetMultiSelect(true).SetCyclic(true).SetShowSelectedItem(true)
+
+	tm := teatest.NewTestModel(t, ModelWrapper{model}, teatest.WithInitialTermSize(80, 24))
+	// 循環して末尾の項目をチェックする
+	tm.Send(tea.KeyMsg{Type: tea.KeyUp})
+	tm.Send(tea.KeyMsg{Type: tea.KeySpace})
+	tm.Send(tea.KeyMsg{Type: tea.KeyDown})
+	tm.Send(tea.KeyMsg{Type: tea.KeyDown})
+	tm.Send(tea.KeyMsg{Type: tea.KeyTab})
+	tm.Send(tea.KeyMsg{Type: tea.KeyEnter})
+	tm.Send(tea.KeyMsg{Type: tea.KeyEsc})
+	tm.WaitFinished(t, teatest.WithFinalTimeout(time.Second))
+
+	final, ok := tm.FinalModel(t).(ModelWrapper)
+	if !ok {
+		t.Fatalf("unexpected final model type %T", tm.FinalModel(t))
+	}
+	var selected []string
+	for _, item := range final.GetSelectedItems() {
+		selected = append(selected, item.String())
+	}
+	if diff := cmp.Diff([]string{"B", "E"}, selected); diff != "" {
+		t.Errorf("GetSelectedItems() mismatch (-want +got):\n%s", diff)
+	}
+	if view := final.View(); !strings.Contains(view, "B, E") {
+		t.Errorf("View() = %q, want the selected items", view)
+	}
+}
+
+func TestMultiSelectView(t *testing.T) {
+	model, err := New(createTestItems(3), 3)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	model = model.SetMultiSelect(true).SetCheckedItems(1)
+
+	view := model.View()
+	for _, want := range []string{"[ ] ", "[x] ", "1 selected"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("View() = %q, want to contain %q", view, want)
+		}
+	}
+}
+
+type richTestItem struct {
+	title       string
+	description string
+	group       string
+	disabled    bool
+}
+
+func (r richTestItem) String() string      { return "string " + r.title }
+func (r richTestItem) Title() string       { return r.title }
+func (r richTestItem) Description() string { return r.description }
+func (r richTestItem) Group() string       { return r.group }
+func (r richTestItem) Disabled() bool      { return r.disabled }
+
+func createRichTestItems() []SelectItem {
+	return []SelectItem{
+		richTestItem{title: "feat", description: "A new feature", group: "Features", disabled: true},
+		richTestItem{title: "perf", description: "Performance", group: "Features"},
+		richTestItem{title: "fix", description: "A bug fix", group: "Fixes"},
+		richTestItem{title: "hotfix", group: "Fixes", disabled: true},
+		richTestItem{title: "docs", group: "Others"},
+	}
+}
+
+func TestRichItem(t *testing.T) {
+	tests := []struct {
+		name         string
+		cyclic       bool
+		keyInputs    []tea.KeyMsg
+		wantCurrent  string
+		wantSelected bool
+	}{
+		{
+			name:        "[正常系] 先頭の無効な項目を飛ばして開始",
+			wantCurrent: "perf",
+		},
+		{
+			name: "[正常系] 下移動で無効な項目を飛ばす",
+			keyInputs: []tea.KeyMsg{
+				{Type: tea.KeyDown},
+				{Type: tea.KeyDown},
+			},
+			wantCurrent: "docs",
+		},
+		{
+			name: "[正常系] 先頭より前に有効な項目が無い場合は移動しない",
+			keyInputs: []tea.KeyMsg{
+				{Type: tea.KeyUp},
+			},
+			wantCurrent: "perf",
+		},
+		{
+			name:   "[正常系] 循環時も無効な項目を飛ばす",
+			cyclic: true,
+			keyInputs: []tea.KeyMsg{
+				{Type: tea.KeyUp},
+			},
+			wantCurrent: "docs",
+		},
+		{
+			name: "[正常系] 有効な項目を選択",
+			keyInputs: []tea.KeyMsg{
+				{Type: tea.KeyDown},
+				{Type: tea.KeyEnter},
+			},
+			wantCurrent:  "fix",
+			wantSelected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model, err := New(createRichTestItems(), 3)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			model = model.SetCyclic(tt.cyclic)
+			for _, msg := range tt.keyInputs {
+				model, _ = model.Update(msg)
+			}
+
+			if got := itemTitle(model.GetCurrentItem()); got != tt.wantCurrent {
+				t.Errorf("GetCurrentItem() = %q, want %q", got, tt.wantCurrent)
+			}
+			if model.IsSelected() != tt.wantSelected {
+				t.Errorf("IsSelected() = %t, want %t", model.IsSelected(), tt.wantSelected)
+			}
+		})
+	}
+}
+
+func TestRichItemDisabled(t *testing.T) {
+	items := []SelectItem{
+		richTestItem{title: "a", disabled: true},
+		richTestItem{title: "b", disabled: true},
+	}
+
+	t.Run("[異常系] すべて無効な場合は選択できない", func(t *testing.T) {
+		model, err := New(items, 3)
+		if err != nil {
+			t.Fatalf("New() error = %v", err)
+		}
+		model, _ = model.Update(tea.KeyMsg{Type: tea.KeyDown})
+		model, _ = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		if model.IsSelected() {
+			t.Error("expected a disabled item not to be selected")
+		}
+	})
+
+	t.Run("[異常系] 無効な項目はチェックできない", func(t *testing.T) {
+		model, err := New(createRichTestItems(), 5)
+		if err != nil {
+			t.Fatalf("New() error = %v", err)
+		}
+		model = model.SetMultiSelect(true)
+		model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+
+		var checked []string
+		for _, item := range model.GetCheckedItems() {
+			checked = append(checked, itemTitle(item))
+		}
+		if diff := cmp.Diff([]string{"perf", "fix", "docs"}, checked); diff != "" {
+			t.Errorf("GetCheckedItems() mismatch (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func TestRichItemView(t *testing.T) {
+	model, err := New(createRichTestItems(), 5)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	view := model.View()
+	for _, want := range []string{"── Features ──", "── Fixes ──", "── Others ──", "perf", "Performance"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("View() = %q, want to contain %q", view, want)
+		}
+	}
+	if strings.Count(view, "── Features ──") != 1 {
+		t.Errorf("View() = %q, want the group header once", view)
+	}
+	if strings.Contains(view, "string ") {
+		t.Errorf("View() = %q, want titles instead of String()", view)
+	}
+
+	model = model.SetShowSelectedItem(true)
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if view := model.View(); !strings.Contains(view, "perf") {
+		t.Errorf("View() = %q, want the selected title", view)
+	}
+}
+
+func TestSetCursor(t *testing.T) {
+	tests := []struct {
+		name             string
+		items            []SelectItem
+		cyclic           bool
+		index            int
+		wantCursor       int
+		wantDisplayRange [2]int
+	}{
+		{
+			name:             "[正常系] 表示範囲内の項目",
+			items:            createTestItems(10),
+			index:            1,
+			wantCursor:       1,
+			wantDisplayRange: [2]int{0, 3},
+		},
+		{
+			name:             "[正常系] 非循環で中央に表示",
+			items:            createTestItems(10),
+			index:            5,
+			wantCursor:       5,
+			wantDisplayRange: [2]int{4, 7},
+		},
+		{
+			name:             "[正常系] 非循環で末尾の項目",
+			items:            createTestItems(10),
+			index:            9,
+			wantCursor:       9,
+			wantDisplayRange: [2]int{7, 10},
+		},
+		{
+			name:             "[正常系] 循環で中央に表示",
+			items:            createTestItems(10),
+			cyclic:           true,
+			index:            5,
+			wantCursor:       5,
+			wantDisplayRange: [2]int{4, 7},
+		},
+		{
+			name:             "[正常系] 循環で末尾の項目は先頭へ回り込んで表示",
+			items:            createTestItems(10),
+			cyclic:           true,
+			index:            9,
+			wantCursor:       9,
+			wantDisplayRange: [2]int{8, 1},
+		},
+		{
+			name:             "[正常系] 項目数が表示数以下",
+			items:            createTestItems(3),
+			index:            2,
+			wantCursor:       2,
+			wantDisplayRange: [2]int{0, 3},
+		},
+		{
+			name:             "[異常系] 範囲外のインデックスは無視",
+			items:            createTestItems(10),
+			index:            10,
+			wantCursor:       0,
+			wantDisplayRange: [2]int{0, 3},
+		},
+		{
+			name:             "[異常系] 無効な項目は無視",
+			items:            createRichTestItems(),
+			index:            3,
+			wantCursor:       1,
+			wantDisplayRange: [2]int{0, 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model, err := New(tt.items, 3)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			model = model.SetCyclic(tt.cyclic).SetCursor(tt.index)
+
+			if model.cursor != tt.wantCursor {
+				t.Errorf("cursor = %d, want %d", model.cursor, tt.wantCursor)
+			}
+			if diff := cmp.Diff(tt.wantDisplayRange, model.displayRange); diff != "" {
+				t.Errorf("displayRange mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSetCursorThenMove(t *testing.T) {
+	// SetCursorの後も上下移動で表示範囲が崩れないこと
+	for _, cyclic := range []bool{false, true} {
+		model, err := New(createTestItems(10), 3)
+		if err != nil {
+			t.Fatalf("New() error = %v", err)
+		}
+		model = model.SetCyclic(cyclic).SetCursor(5)
+		for range 12 {
+			model, _ = model.Update(tea.KeyMsg{Type: tea.KeyDown})
+			if !slices.Contains(model.displayedPositions(), model.cursor) {
+				t.Fatalf("cyclic=%t: cursor %d is out of %v", cyclic, model.cursor, model.displayRange)
+			}
+		}
+		for range 12 {
+			model, _ = model.Update(tea.KeyMsg{Type: tea.KeyUp})
+			if !slices.Contains(model.displayedPositions(), model.cursor) {
+				t.Fatalf("cyclic=%t: cursor %d is out of %v", cyclic, model.cursor, model.displayRange)
+			}
+		}
+	}
+}
+
+func TestHotkeys(t *testing.T) {
+	items := []SelectItem{
+		testItem{value: "apple"},
+		testItem{value: "banana"},
+		testItem{value: "blueberry"},
+		testItem{value: "cherry"},
+		testItem{value: "kiwi"},
+	}
+
+	tests := []struct {
+		name         string
+		multiSelect  bool
+		filterable   bool
+		keyInputs    []tea.KeyMsg
+		wantCurrent  string
+		wantSelected bool
+		wantChecked  []string
+	}{
+		{
+			name: "[正常系] 数字で表示中の項目を選択",
+			keyInputs: []tea.KeyMsg{
+				{Type: tea.KeyRunes, Runes: []rune("2")},
+			},
+			wantCurrent:  "banana",
+			wantSelected: true,
+		},
+		{
+			name: "[正常系] 表示数を超える数字は無視",
+			keyInputs: []tea.KeyMsg{
+				{Type: tea.KeyRunes, Runes: []rune("5")},
+			},
+			wantCurrent: "apple",
+		},
+		{
+			name: "[正常系] 文字で始まる次の項目へ移動",
+			keyInputs: []tea.KeyMsg{
+				{Type: tea.KeyRunes, Runes: []rune("b")},
+				{Type: tea.KeyRunes, Runes: []rune("b")},
+			},
+			wantCurrent: "blueberry",
+		},
+		{
+			name: "[正常系] 末尾から先頭へ回り込んで探す",
+			keyInputs: []tea.KeyMsg{
+				{Type: tea.KeyRunes, Runes: []rune("c")},
+				{Type: tea.KeyRunes, Runes: []rune("A")},
+			},
+			wantCurrent: "apple",
+		},
+		{
+			name: "[正常系] キーマップの文字は移動に使う",
+			keyInputs: []tea.KeyMsg{
+				{Type: tea.KeyRunes, Runes: []rune("k")},
+			},
+			wantCurrent: "apple",
+		},
+		{
+			name:        "[正常系] 複数選択では数字でチェックを切り替える",
+			multiSelect: true,
+			keyInputs: []tea.KeyMsg{
+				{Type: tea.KeyRunes, Runes: []rune("1")},
+				{Type: tea.KeyRunes, Runes: []rune("3")},
+			},
+			wantCurrent: "blueberry",
+			wantChecked: []string{"apple", "blueberry"},
+		},
+		{
+			name:       "[正常系] 絞り込み中は文字を絞り込みに使う",
+			filterable: true,
+			keyInputs: []tea.KeyMsg{
+				{Type: tea.KeyRunes, Runes: []rune("c")},
+			},
+			wantCurrent: "cherry",
+		},
+		{
+			name:       "[正常系] 絞り込みが有効でも数字で絞り込んだ項目を選択",
+			filterable: true,
+			keyInputs: []tea.KeyMsg{
+				{Type: tea.KeyRunes, Runes: []rune("b")},
+				{Type: tea.KeyRunes, Runes: []rune("2")},
+			},
+			wantCurrent:  "blueberry",
+			wantSelected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model, err := New(items, 3)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			model = model.SetHotkeys(true).SetMultiSelect(tt.multiSelect).SetFilterable(tt.filterable)
+			for _, msg := range tt.keyInputs {
+				model, _ = model.Update(msg)
+			}
+
+			if got := model.GetCurrentItem().String(); got != tt.wantCurrent {
+				t.Errorf("GetCurrentItem() = %q, want %q", got, tt.wantCurrent)
+			}
+			if model.IsSelected() != tt.wantSelected {
+				t.Errorf("IsSelected() = %t, want %t", model.IsSelected(), tt.wantSelected)
+			}
+			var checked []string
+			for _, item := range model.GetCheckedItems() {
+				checked = append(checked, item.String())
+			}
+			if diff := cmp.Diff(tt.wantChecked, checked, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("GetCheckedItems() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPositionIndicator(t *testing.T) {
+	tests := []struct {
+		name      string
+		items     []SelectItem
+		index     int
+		hotkeys   bool
+		wantViews []string
+		wantNot   []string
+	}{
+		{
+			name:      "[正常系] 表示数を超える場合に位置を表示",
+			items:     createTestItems(15),
+			index:     2,
+			wantViews: []string{"3/15"},
+		},
+		{
+			name:    "[正常系] 表示数以下では位置を表示しない",
+			items:   createTestItems(3),
+			wantNot: []string{"/3"},
+		},
+		{
+			name:      "[正常系] 数字のキーを表示",
+			items:     createTestItems(3),
+			hotkeys:   true,
+			wantViews: []string{"1 A", "2 B", "3 C"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model, err := New(tt.items, 3)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			view := model.SetHotkeys(tt.hotkeys).SetCursor(tt.index).View()
+			for _, want := range tt.wantViews {
+				if !strings.Contains(view, want) {
+					t.Errorf("View() = %q, want to contain %q", view, want)
+				}
+			}
+			for _, not := range tt.wantNot {
+				if strings.Contains(view, not) {
+					t.Errorf("View() = %q, want not to contain %q", view, not)
+				}
+			}
+		})
+	}
+}
+
+func TestMouse(t *testing.T) {
+	press := func(button tea.MouseButton, x, y int) tea.MouseMsg {
+		return tea.MouseMsg{X: x, Y: y, Action: tea.MouseActionPress, Button: button}
+	}
+
+	tests := []struct {
+		name         string
+		items        []SelectItem
+		multiSelect  bool
+		msgs         []tea.MouseMsg
+		wantCurrent  string
+		wantSelected bool
+		wantChecked  []string
+	}{
+		{
+			// 上の余白2行の後に項目を表示する
+			name:         "[正常系] クリックした項目を選択",
+			items:        createTestItems(5),
+			msgs:         []tea.MouseMsg{press(tea.MouseButtonLeft, 4, 3)},
+			wantCurrent:  "B",
+			wantSelected: true,
+		},
+		{
+			name:        "[正常系] 左の余白のクリックは無視",
+			items:       createTestItems(5),
+			msgs:        []tea.MouseMsg{press(tea.MouseButtonLeft, 1, 3)},
+			wantCurrent: "A",
+		},
+		{
+			name:        "[正常系] 一覧の外のクリックは無視",
+			items:       createTestItems(5),
+			msgs:        []tea.MouseMsg{press(tea.MouseButtonLeft, 4, 0), press(tea.MouseButtonLeft, 4, 5)},
+			wantCurrent: "A",
+		},
+		{
+			name:        "[正常系] ホイールで表示範囲内をスクロール",
+			items:       createTestItems(5),
+			msgs:        []tea.MouseMsg{press(tea.MouseButtonWheelDown, 0, 0), press(tea.MouseButtonWheelDown, 0, 0), press(tea.MouseButtonWheelUp, 0, 0)},
+			wantCurrent: "B",
+		},
+		{
+			name:        "[正常系] 複数選択ではクリックでチェックを切り替える",
+			items:       createTestItems(5),
+			multiSelect: true,
+			msgs:        []tea.MouseMsg{press(tea.MouseButtonLeft, 4, 2), press(tea.MouseButtonLeft, 4, 4)},
+			wantCurrent: "C",
+			wantChecked: []string{"A", "C"},
+		},
+		{
+			// 見出しの行はクリックできず、無効な項目は選択できない
+			name:        "[異常系] 見出しや無効な項目のクリックは無視",
+			items:       createRichTestItems(),
+			msgs:        []tea.MouseMsg{press(tea.MouseButtonLeft, 4, 2), press(tea.MouseButtonLeft, 4, 3)},
+			wantCurrent: "perf",
+		},
+		{
+			name:         "[正常系] 見出しの下の項目をクリック",
+			items:        createRichTestItems(),
+			msgs:         []tea.MouseMsg{press(tea.MouseButtonLeft, 4, 6)},
+			wantCurrent:  "fix",
+			wantSelected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model, err := New(tt.items, 3)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			model = model.SetMultiSelect(tt.multiSelect)
+			for _, msg := range tt.msgs {
+				model, _ = model.Update(msg)
+			}
+
+			if got := itemTitle(model.GetCurrentItem()); got != tt.wantCurrent {
+				t.Errorf("GetCurrentItem() = %q, want %q\n%s", got, tt.wantCurrent, model.View())
+			}
+			if model.IsSelected() != tt.wantSelected {
+				t.Errorf("IsSelected() = %t, want %t", model.IsSelected(), tt.wantSelected)
+			}
+			var checked []string
+			for _, item := range model.GetCheckedItems() {
+				checked = append(checked, itemTitle(item))
+			}
+			if diff := cmp.Diff(tt.wantChecked, checked, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("GetCheckedItems() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestHelp(t *testing.T) {
+	tests := []struct {
+		name      string
+		multi     bool
+		wantShort []string
+		wantFull  [][]string
+	}{
+		{
+			name:      "[正常系] 単一選択",
+			wantShort: []string{"move up", "move down", "select item", "quit"},
+			wantFull:  [][]string{{"move up", "move down"}, {"select item", "quit"}},
+		},
+		{
+			name:      "[正常系] 複数選択では切り替えのキーを含む",
+			multi:     true,
+			wantShort: []string{"move up", "move down", "toggle item", "select item", "quit"},
+			wantFull:  [][]string{{"move up", "move down"}, {"toggle item", "select all items", "invert selection"}, {"select item", "quit"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model, err := New(createTestItems(3), 3)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			model = model.SetMultiSelect(tt.multi)
+
+			var short []string
+			for _, b := range model.ShortHelp() {
+				short = append(short, b.Help().Desc)
+			}
+			if diff := cmp.Diff(tt.wantShort, short); diff != "" {
+				t.Errorf("ShortHelp() mismatch (-want +got):\n%s", diff)
+			}
+			var full [][]string
+			for _, column := range model.FullHelp() {
+				var descs []string
+				for _, b := range column {
+					descs = append(descs, b.Help().Desc)
+				}
+				full = append(full, descs)
+			}
+			if diff := cmp.Diff(tt.wantFull, full); diff != "" {
+				t.Errorf("FullHelp() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSetSize(t *testing.T) {
+	longItems := []SelectItem{
+		testItem{value: "✨ feat: 新しい機能を追加する変更"},
+		testItem{value: "🐛 fix: バグを修正する変更"},
+		testItem{value: "📝 docs: ドキュメントのみの変更"},
+		testItem{value: "♻️ refactor: 機能を変えない整理"},
+		testItem{value: "✅ test: テストの追加や修正"},
+	}
+
+	tests := []struct {
+		name          string
+		items         []SelectItem
+		width         int
+		height        int
+		multiSelect   bool
+		wantViewSize  int
+		wantTruncated bool
+	}{
+		{
+			name:         "[正常系] 制限が無ければ表示数のまま",
+			items:        longItems,
+			wantViewSize: 5,
+		},
+		{
+			name:          "[正常系] 絵文字や全角文字の項目を幅で切り詰める",
+			items:         longItems,
+			width:         24,
+			wantViewSize:  5,
+			wantTruncated: true,
+		},
+		{
+			name:         "[正常系] 高さが足りない場合は余白を詰めて表示数を減らす",
+			items:        longItems,
+			height:       7,
+			wantViewSize: 3,
+		},
+		{
+			name:         "[正常系] 見出しの行も高さに含める",
+			items:        createRichTestItems(),
+			height:       10,
+			wantViewSize: 3,
+		},
+		{
+			name:         "[異常系] 高さが極端に小さくても1件は表示",
+			items:        longItems,
+			height:       1,
+			multiSelect:  true,
+			wantViewSize: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model, err := New(tt.items, 5)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			model = model.SetMultiSelect(tt.multiSelect).SetSize(tt.width, tt.height)
+			if got := model.viewSize(); got != tt.wantViewSize {
+				t.Errorf("viewSize() = %d, want %d", got, tt.wantViewSize)
+			}
+
+			view := model.View()
+			for _, line := range strings.Split(view, "\n") {
+				if w := lipgloss.Width(line); tt.width > 0 && w > tt.width {
+					t.Errorf("line %q has width %d, want at most %d", line, w, tt.width)
+				}
+			}
+			if tt.height > 1 {
+				if got := lipgloss.Height(view); got > tt.height {
+					t.Errorf("View() has %d lines, want at most %d\n%s", got, tt.height, view)
+				}
+			}
+			if got := strings.Contains(view, "…"); got != tt.wantTruncated {
+				t.Errorf("View() truncated = %v, want %v\n%s", got, tt.wantTruncated, view)
+			}
+		})
+	}
+}
+
+func TestSetRenderer(t *testing.T) {
+	tests := []struct {
+		name      string
+		profile   termenv.Profile
+		wantColor bool
+	}{
+		{
+			name:      "[正常系] 色を扱える出力の renderer では色を付ける",
+			profile:   termenv.TrueColor,
+			wantColor: true,
+		},
+		{
+			name:      "[正常系] 色を扱えない出力の renderer では色を付けない",
+			profile:   termenv.Ascii,
+			wantColor: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model, err := New(createTestItems(3), 3)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			r := lipgloss.NewRenderer(io.Discard)
+			r.SetColorProfile(tt.profile)
+			view := model.SetTheme(theme.Dark).SetRenderer(r).View()
+			// 24bit の前景色か背景色の指定が含まれるか
+			if got := strings.Contains(view, "8;2;"); got != tt.wantColor {
+				t.Errorf("View() colored = %v, want %v: %q", got, tt.wantColor, view)
+			}
+		})
+	}
+}
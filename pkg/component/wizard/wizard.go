@@ -0,0 +1,160 @@
+package wizard
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Step is a single question of the wizard
+type Step interface {
+	Init() tea.Cmd
+	Update(msg tea.Msg) (Step, tea.Cmd)
+	View() string
+	IsFinished() bool
+	Value() any
+	// Focus makes the step editable again while keeping its value and focuses its input
+	Focus() Step
+}
+
+// Entry is a step with the id it is referred to by
+type Entry struct {
+	ID   string
+	Step Step
+}
+
+// SkipFunc reports whether the step with the id is not asked
+type SkipFunc func(id string) bool
+
+// Model asks the steps in the order they were given.
+// Whether a step is asked is decided when moving between the steps, so a step can depend on the answers before it.
+type Model struct {
+	ids     []string        // Step ids in the order they are asked
+	steps   map[string]Step // Steps keyed by their id
+	current int             // Index of the current step
+}
+
+func New(entries ...Entry) (Model, error) {
+	if len(entries) == 0 {
+		return Model{}, errors.New("wizard needs at least one step")
+	}
+
+	m := Model{steps: map[string]Step{}}
+	for _, e := range entries {
+		switch {
+		case e.ID == "":
+			return Model{}, errors.New("step id must not be empty")
+		case e.Step == nil:
+			return Model{}, fmt.Errorf("step %s is nil", e.ID)
+		case m.steps[e.ID] != nil:
+			return Model{}, fmt.Errorf("duplicate step id: %s", e.ID)
+		}
+		m.ids = append(m.ids, e.ID)
+		m.steps[e.ID] = e.Step
+	}
+	return m, nil
+}
+
+// IDs returns the ids of every step in order
+func (m Model) IDs() []string {
+	return slices.Clone(m.ids)
+}
+
+// Current returns the id of the current step
+func (m Model) Current() string {
+	return m.ids[m.current]
+}
+
+// CurrentStep returns the current step
+func (m Model) CurrentStep() Step {
+	return m.steps[m.Current()]
+}
+
+// Step returns the step with the id, or nil if there is none
+func (m Model) Step(id string) Step {
+	return m.steps[id]
+}
+
+// SetStep replaces the step with the id
+func (m Model) SetStep(id string, s Step) Model {
+	if _, ok := m.steps[id]; !ok {
+		return m
+	}
+	// 他のコピーと状態を共有しないようにする
+	m.steps = maps.Clone(m.steps)
+	m.steps[id] = s
+	return m
+}
+
+// First returns the id of the first asked step, or the last step when every step is skipped
+func (m Model) First(skip SkipFunc) string {
+	for _, id := range m.ids {
+		if !skip(id) {
+			return id
+		}
+	}
+	return m.ids[len(m.ids)-1]
+}
+
+// Next returns the id of the closest asked step after the current one
+func (m Model) Next(skip SkipFunc) (string, bool) {
+	for _, id := range m.ids[m.current+1:] {
+		if !skip(id) {
+			return id, true
+		}
+	}
+	return "", false
+}
+
+// Prev returns the id of the closest asked step before the current one
+func (m Model) Prev(skip SkipFunc) (string, bool) {
+	for i := m.current - 1; i >= 0; i-- {
+		if !skip(m.ids[i]) {
+			return m.ids[i], true
+		}
+	}
+	return "", false
+}
+
+// Completed returns the ids of the steps before the current one that are not skipped
+func (m Model) Completed(skip SkipFunc) []string {
+	var ids []string
+	for _, id := range m.ids[:m.current] {
+		if !skip(id) {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// Jump makes the step with the id current and focuses it
+func (m Model) Jump(id string) Model {
+	i := slices.Index(m.ids, id)
+	if i < 0 {
+		return m
+	}
+	m.current = i
+	return m.SetStep(id, m.steps[id].Focus())
+}
+
+func (m Model) Init() tea.Cmd {
+	cmds := make([]tea.Cmd, len(m.ids))
+	for i, id := range m.ids {
+		cmds[i] = m.steps[id].Init()
+	}
+	return tea.Batch(cmds...)
+}
+
+// Update passes the message to the current step
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	step, cmd := m.CurrentStep().Update(msg)
+	return m.SetStep(m.Current(), step), cmd
+}
+
+// View renders the current step
+func (m Model) View() string {
+	return m.CurrentStep().View()
+}
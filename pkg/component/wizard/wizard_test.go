@@ -0,0 +1,154 @@
+package wizard
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/go-cmp/cmp"
+)
+
+// testStep は入力された文字を値とし、Enterで完了するステップ
+type testStep struct {
+	value    string
+	finished bool
+	focused  int
+}
+
+func (s testStep) Init() tea.Cmd    { return nil }
+func (s testStep) View() string     { return s.value }
+func (s testStep) IsFinished() bool { return s.finished }
+func (s testStep) Value() any       { return s.value }
+
+func (s testStep) Update(msg tea.Msg) (Step, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		if msg.Type == tea.KeyEnter {
+			s.finished = true
+		} else {
+			s.value += msg.String()
+		}
+	}
+	return s, nil
+}
+
+func (s testStep) Focus() Step {
+	s.finished = false
+	s.focused++
+	return s
+}
+
+func createTestWizard(t *testing.T) Model {
+	t.Helper()
+	m, err := New(
+		Entry{ID: "a", Step: testStep{}},
+		Entry{ID: "b", Step: testStep{}},
+		Entry{ID: "c", Step: testStep{}},
+	)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return m
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []Entry
+		wantErr bool
+	}{
+		{
+			name:    "[正常系] ステップを順に登録",
+			entries: []Entry{{ID: "a", Step: testStep{}}, {ID: "b", Step: testStep{}}},
+		},
+		{
+			name:    "[異常系] ステップが無い",
+			wantErr: true,
+		},
+		{
+			name:    "[異常系] 重複したid",
+			entries: []Entry{{ID: "a", Step: testStep{}}, {ID: "a", Step: testStep{}}},
+			wantErr: true,
+		},
+		{
+			name:    "[異常系] 空のid",
+			entries: []Entry{{Step: testStep{}}},
+			wantErr: true,
+		},
+		{
+			name:    "[異常系] ステップがnil",
+			entries: []Entry{{ID: "a"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.entries...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNavigation(t *testing.T) {
+	skipB := func(id string) bool { return id == "b" }
+	skipAll := func(string) bool { return true }
+
+	m := createTestWizard(t)
+	if got := m.First(skipB); got != "a" {
+		t.Errorf("First() = %v, want a", got)
+	}
+	if got := m.First(skipAll); got != "c" {
+		t.Errorf("First() with every step skipped = %v, want c", got)
+	}
+
+	next, ok := m.Next(skipB)
+	if next != "c" || !ok {
+		t.Errorf("Next() = %v, %v, want c, true", next, ok)
+	}
+	if _, ok := m.Prev(skipB); ok {
+		t.Errorf("Prev() at the first step should not find a step")
+	}
+
+	m = m.Jump("c")
+	if got := m.Current(); got != "c" {
+		t.Errorf("Current() = %v, want c", got)
+	}
+	if _, ok := m.Next(skipB); ok {
+		t.Errorf("Next() at the last step should not find a step")
+	}
+	prev, ok := m.Prev(skipB)
+	if prev != "a" || !ok {
+		t.Errorf("Prev() = %v, %v, want a, true", prev, ok)
+	}
+	if diff := cmp.Diff([]string{"a"}, m.Completed(skipB)); diff != "" {
+		t.Errorf("Completed() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	m := createTestWizard(t)
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	if !m.CurrentStep().IsFinished() {
+		t.Errorf("IsFinished() = false, want true")
+	}
+	if got := m.Step("a").Value(); got != "x" {
+		t.Errorf("Value() = %v, want x", got)
+	}
+	if got := m.View(); got != "x" {
+		t.Errorf("View() = %v, want x", got)
+	}
+
+	// 戻ったステップは値を残したまま再び編集できる
+	jumped := m.Jump("b").Jump("a")
+	step := jumped.Step("a").(testStep)
+	if step.finished || step.value != "x" || step.focused != 1 {
+		t.Errorf("Jump() step = %+v, want unfinished with value x focused once", step)
+	}
+	// 元のモデルのステップは変更されない
+	if !m.Step("a").IsFinished() {
+		t.Errorf("Jump() changed the step of the original model")
+	}
+}
@@ -0,0 +1,175 @@
+package theme
+
+import (
+	"os"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Theme holds the colors and the border shared by the components
+type Theme struct {
+	Accent       lipgloss.TerminalColor // Cursor, icons and selected values
+	Muted        lipgloss.TerminalColor // Hints, counters and disabled items
+	Error        lipgloss.TerminalColor
+	Warning      lipgloss.TerminalColor
+	Group        lipgloss.TerminalColor // Group headers of lists
+	Description  lipgloss.TerminalColor // Description of the item under the cursor
+	ButtonFg     lipgloss.TerminalColor // Label of the selected button
+	ButtonBg     lipgloss.TerminalColor // Background of the selected button
+	ButtonIdleBg lipgloss.TerminalColor // Background of the other buttons
+	Border       lipgloss.Border
+
+	// ButtonMarks are put around the label of the selected button, for themes whose colors do not show the selection
+	ButtonMarks [2]string
+}
+
+// palette は1つの背景色に合わせた配色
+type palette struct {
+	accent, muted, error, warning, group, description, buttonFg, buttonBg, buttonIdleBg string
+}
+
+var (
+	darkPalette = palette{
+		accent:       "#bb9af7",
+		muted:        "#696969",
+		error:        "#ff0000",
+		warning:      "#e0af68",
+		group:        "#7aa2f7",
+		description:  "#a9b1d6",
+		buttonFg:     "#ffffff",
+		buttonBg:     "#bb9af7",
+		buttonIdleBg: "#1e1e2e",
+	}
+	lightPalette = palette{
+		accent:       "#7847bd",
+		muted:        "#6c6f85",
+		error:        "#d20f39",
+		warning:      "#b35900",
+		group:        "#1e66f5",
+		description:  "#4c4f69",
+		buttonFg:     "#ffffff",
+		buttonBg:     "#7847bd",
+		buttonIdleBg: "#e6e9ef",
+	}
+	// 端末の16色のみを使い、背景色に合わせて白黒に近い色にする
+	highContrastLight = palette{
+		accent:       "4",
+		muted:        "0",
+		error:        "1",
+		warning:      "5",
+		group:        "4",
+		description:  "0",
+		buttonFg:     "15",
+		buttonBg:     "0",
+		buttonIdleBg: "7",
+	}
+	highContrastDark = palette{
+		accent:       "14",
+		muted:        "15",
+		error:        "9",
+		warning:      "11",
+		group:        "14",
+		description:  "15",
+		buttonFg:     "0",
+		buttonBg:     "15",
+		buttonIdleBg: "8",
+	}
+)
+
+var (
+	// Dark is the theme for terminals with a dark background
+	Dark = solid(darkPalette, lipgloss.RoundedBorder())
+	// Light is the theme for terminals with a light background
+	Light = solid(lightPalette, lipgloss.RoundedBorder())
+	// Auto picks the light or the dark colors from the background of the terminal
+	Auto = adaptive(lightPalette, darkPalette, lipgloss.RoundedBorder())
+	// HighContrast uses the basic terminal colors and thick borders
+	HighContrast = adaptive(highContrastLight, highContrastDark, lipgloss.ThickBorder())
+	// None has no colors and marks the selected button with brackets
+	None = Theme{
+		Accent:       lipgloss.NoColor{},
+		Muted:        lipgloss.NoColor{},
+		Error:        lipgloss.NoColor{},
+		Warning:      lipgloss.NoColor{},
+		Group:        lipgloss.NoColor{},
+		Description:  lipgloss.NoColor{},
+		ButtonFg:     lipgloss.NoColor{},
+		ButtonBg:     lipgloss.NoColor{},
+		ButtonIdleBg: lipgloss.NoColor{},
+		Border:       lipgloss.NormalBorder(),
+		ButtonMarks:  [2]string{"[", "]"},
+	}
+
+	// Default is the theme used when none is set
+	Default = Auto
+)
+
+// presets は設定で指定できるテーマの名前
+var presets = map[string]Theme{
+	"auto":          Auto,
+	"dark":          Dark,
+	"light":         Light,
+	"high-contrast": HighContrast,
+	"none":          None,
+}
+
+// borders は設定で指定できる枠線の名前
+var borders = map[string]lipgloss.Border{
+	"rounded": lipgloss.RoundedBorder(),
+	"normal":  lipgloss.NormalBorder(),
+	"thick":   lipgloss.ThickBorder(),
+	"double":  lipgloss.DoubleBorder(),
+	"hidden":  lipgloss.HiddenBorder(),
+}
+
+// solid は1つの配色で固定したテーマを作る
+func solid(p palette, border lipgloss.Border) Theme {
+	return Theme{
+		Accent:       lipgloss.Color(p.accent),
+		Muted:        lipgloss.Color(p.muted),
+		Error:        lipgloss.Color(p.error),
+		Warning:      lipgloss.Color(p.warning),
+		Group:        lipgloss.Color(p.group),
+		Description:  lipgloss.Color(p.description),
+		ButtonFg:     lipgloss.Color(p.buttonFg),
+		ButtonBg:     lipgloss.Color(p.buttonBg),
+		ButtonIdleBg: lipgloss.Color(p.buttonIdleBg),
+		Border:       border,
+	}
+}
+
+// adaptive は端末の背景色に合わせて2つの配色から選ぶテーマを作る
+func adaptive(light, dark palette, border lipgloss.Border) Theme {
+	color := func(l, d string) lipgloss.AdaptiveColor {
+		return lipgloss.AdaptiveColor{Light: l, Dark: d}
+	}
+	return Theme{
+		Accent:       color(light.accent, dark.accent),
+		Muted:        color(light.muted, dark.muted),
+		Error:        color(light.error, dark.error),
+		Warning:      color(light.warning, dark.warning),
+		Group:        color(light.group, dark.group),
+		Description:  color(light.description, dark.description),
+		ButtonFg:     color(light.buttonFg, dark.buttonFg),
+		ButtonBg:     color(light.buttonBg, dark.buttonBg),
+		ButtonIdleBg: color(light.buttonIdleBg, dark.buttonIdleBg),
+		Border:       border,
+	}
+}
+
+// Preset returns the built-in theme with the name
+func Preset(name string) (Theme, bool) {
+	t, ok := presets[name]
+	return t, ok
+}
+
+// Border returns the border with the name
+func Border(name string) (lipgloss.Border, bool) {
+	b, ok := borders[name]
+	return b, ok
+}
+
+// NoColor reports whether the NO_COLOR environment variable asks for output without colors
+func NoColor() bool {
+	return os.Getenv("NO_COLOR") != ""
+}
@@ -0,0 +1,98 @@
+package theme
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPreset(t *testing.T) {
+	tests := []struct {
+		name   string
+		preset string
+		want   Theme
+		wantOK bool
+	}{
+		{
+			name:   "[正常系] 高コントラストのテーマ",
+			preset: "high-contrast",
+			want:   HighContrast,
+			wantOK: true,
+		},
+		{
+			name:   "[正常系] 色の無いテーマ",
+			preset: "none",
+			want:   None,
+			wantOK: true,
+		},
+		{
+			name:   "[異常系] 存在しない名前",
+			preset: "solarized",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Preset(tt.preset)
+			if ok != tt.wantOK {
+				t.Fatalf("Preset() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Preset() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestBorder(t *testing.T) {
+	tests := []struct {
+		name   string
+		border string
+		want   lipgloss.Border
+		wantOK bool
+	}{
+		{
+			name:   "[正常系] 二重線の枠",
+			border: "double",
+			want:   lipgloss.DoubleBorder(),
+			wantOK: true,
+		},
+		{
+			name:   "[異常系] 存在しない名前",
+			border: "dotted",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Border(tt.border)
+			if ok != tt.wantOK {
+				t.Fatalf("Border() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Border() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNoColor(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  bool
+	}{
+		{name: "[正常系] NO_COLORを設定", value: "1", want: true},
+		{name: "[正常系] 空のNO_COLORは無視", value: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("NO_COLOR", tt.value)
+			if got := NoColor(); got != tt.want {
+				t.Errorf("NoColor() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}